@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const defaultReconnectInitialBackoff = 1 * time.Second
+const defaultReconnectMaxBackoff = 30 * time.Second
+const defaultReconnectBackoffMultiplier = 2.0
+const defaultReconnectJitter = 0.2
+
+// backoff computes exponentially growing delays between attempts. Each delay is randomized by up to jitter (a fraction
+// of the delay) in either direction so that many clients do not retry in lockstep.
+type backoff struct {
+	initial    time.Duration
+	max        time.Duration
+	multiplier float64
+	jitter     float64
+}
+
+// delay returns the time to wait before the given attempt, where attempt 0 is the first retry.
+func (b *backoff) delay(attempt int) time.Duration {
+	d := float64(b.initial) * math.Pow(b.multiplier, float64(attempt))
+	if b.max > 0 && d > float64(b.max) {
+		d = float64(b.max)
+	}
+	if b.jitter > 0 {
+		d += d * b.jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
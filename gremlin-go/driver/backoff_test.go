@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Run("Test delay grows exponentially up to the maximum", func(t *testing.T) {
+		b := &backoff{initial: 100 * time.Millisecond, max: time.Second, multiplier: 2}
+		assert.Equal(t, 100*time.Millisecond, b.delay(0))
+		assert.Equal(t, 200*time.Millisecond, b.delay(1))
+		assert.Equal(t, 800*time.Millisecond, b.delay(3))
+		assert.Equal(t, time.Second, b.delay(4))
+		assert.Equal(t, time.Second, b.delay(100))
+	})
+
+	t.Run("Test delay stays within jitter", func(t *testing.T) {
+		b := &backoff{initial: time.Second, max: time.Minute, multiplier: 2, jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			d := b.delay(1)
+			assert.True(t, d >= time.Second && d <= 3*time.Second, "delay %v out of range", d)
+		}
+	})
+}
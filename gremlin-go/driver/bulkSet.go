@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"reflect"
+)
+
+// BulkSet is a collection of values each with a count of how often it occurs, as returned by side effect steps such
+// as aggregate(). Values are kept in the order they were added and are only repeated when the BulkSet is expanded.
+type BulkSet struct {
+	values []interface{}
+	bulks  []int64
+}
+
+// NewBulkSet creates an empty BulkSet.
+func NewBulkSet() *BulkSet {
+	return &BulkSet{}
+}
+
+// Add adds a value to the BulkSet bulk times. Values are considered to be the same according to reflect.DeepEqual.
+func (b *BulkSet) Add(value interface{}, bulk int64) {
+	for i, v := range b.values {
+		if reflect.DeepEqual(v, value) {
+			b.bulks[i] += bulk
+			return
+		}
+	}
+	b.add(value, bulk)
+}
+
+// add adds a value which is not yet part of the BulkSet.
+func (b *BulkSet) add(value interface{}, bulk int64) {
+	b.values = append(b.values, value)
+	b.bulks = append(b.bulks, bulk)
+}
+
+// Values returns the distinct values of the BulkSet.
+func (b *BulkSet) Values() []interface{} {
+	return b.values
+}
+
+// Count returns how often the value occurs in the BulkSet.
+func (b *BulkSet) Count(value interface{}) int64 {
+	for i, v := range b.values {
+		if reflect.DeepEqual(v, value) {
+			return b.bulks[i]
+		}
+	}
+	return 0
+}
+
+// Len returns the number of distinct values of the BulkSet.
+func (b *BulkSet) Len() int {
+	return len(b.values)
+}
+
+// Size returns the number of values of the BulkSet, counting each value as often as it occurs.
+func (b *BulkSet) Size() int64 {
+	var size int64
+	for _, bulk := range b.bulks {
+		size += bulk
+	}
+	return size
+}
+
+// Each calls fn with each value of the BulkSet, as often as the value occurs, without expanding the BulkSet. Iteration
+// stops if fn returns false.
+func (b *BulkSet) Each(fn func(value interface{}) bool) {
+	for i, v := range b.values {
+		for j := int64(0); j < b.bulks[i]; j++ {
+			if !fn(v) {
+				return
+			}
+		}
+	}
+}
+
+// Expand returns a slice in which each value of the BulkSet is repeated as often as it occurs.
+func (b *BulkSet) Expand() []interface{} {
+	var expanded []interface{}
+	b.Each(func(value interface{}) bool {
+		expanded = append(expanded, value)
+		return true
+	})
+	return expanded
+}
+
+// expandBulkSets replaces the BulkSets in a value read from a response, including those nested in slices and maps,
+// with their expansion. Slices and maps are changed in place.
+func expandBulkSets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *BulkSet:
+		for i := range v.values {
+			v.values[i] = expandBulkSets(v.values[i])
+		}
+		return v.Expand()
+	case []interface{}:
+		for i := range v {
+			v[i] = expandBulkSets(v[i])
+		}
+	case map[interface{}]interface{}:
+		for key, element := range v {
+			v[key] = expandBulkSets(element)
+		}
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkSet(t *testing.T) {
+	t.Run("Test Add and Count", func(t *testing.T) {
+		bulkSet := NewBulkSet()
+		bulkSet.Add("a", 2)
+		bulkSet.Add([]interface{}{"b"}, 1)
+		bulkSet.Add("a", 3)
+		assert.Equal(t, int64(5), bulkSet.Count("a"))
+		assert.Equal(t, int64(1), bulkSet.Count([]interface{}{"b"}))
+		assert.Equal(t, int64(0), bulkSet.Count("c"))
+		assert.Equal(t, []interface{}{"a", []interface{}{"b"}}, bulkSet.Values())
+		assert.Equal(t, 2, bulkSet.Len())
+		assert.Equal(t, int64(6), bulkSet.Size())
+	})
+
+	t.Run("Test Each and Expand", func(t *testing.T) {
+		bulkSet := NewBulkSet()
+		bulkSet.Add("a", 2)
+		bulkSet.Add("b", 1)
+		assert.Equal(t, []interface{}{"a", "a", "b"}, bulkSet.Expand())
+		assert.Nil(t, NewBulkSet().Expand())
+
+		large := NewBulkSet()
+		large.Add("x", 1<<40)
+		count := 0
+		large.Each(func(value interface{}) bool {
+			count++
+			return count < 10
+		})
+		assert.Equal(t, 10, count)
+	})
+
+	t.Run("Test expandBulkSets", func(t *testing.T) {
+		inner := NewBulkSet()
+		inner.Add("x", 2)
+		outer := NewBulkSet()
+		outer.Add(inner, 2)
+		data := []interface{}{"a", outer, map[interface{}]interface{}{"k": inner}}
+		expanded := expandBulkSets(data)
+		assert.Equal(t, []interface{}{"a", []interface{}{[]interface{}{"x", "x"}, []interface{}{"x", "x"}},
+			map[interface{}]interface{}{"k": []interface{}{"x", "x"}}}, expanded)
+		assert.Equal(t, "a", expandBulkSets("a"))
+	})
+}
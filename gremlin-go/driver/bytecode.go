@@ -136,9 +136,15 @@ func (bytecode *Bytecode) convertArgument(arg interface{}) (interface{}, error)
 				Key:   v.Key,
 				Value: convertedValue,
 			}, nil
+		case *StructProperties:
+			properties, err := v.Map()
+			if err != nil {
+				return nil, err
+			}
+			return bytecode.convertArgument(properties)
 		case *GraphTraversal:
 			if v.graph != nil {
-				return nil, newError(err1001ConvertArgumentChildTraversalNotFromAnonError)
+				return nil, newError(E1001ConvertArgumentChildTraversalNotFromAnonError)
 			}
 			for k, val := range v.Bytecode.bindings {
 				bytecode.bindings[k] = val
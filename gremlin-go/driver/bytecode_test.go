@@ -140,7 +140,7 @@ func TestBytecode(t *testing.T) {
 		traversal.graph = &Graph{}
 		traversal.Bytecode = &Bytecode{}
 		traversalBytecode, err := bc.convertArgument(traversal)
-		assert.Equal(t, newError(err1001ConvertArgumentChildTraversalNotFromAnonError), err)
+		assert.Equal(t, newError(E1001ConvertArgumentChildTraversalNotFromAnonError), err)
 		assert.Nil(t, traversalBytecode)
 	})
 
@@ -20,6 +20,7 @@ under the License.
 package gremlingo
 
 import (
+	"context"
 	"crypto/tls"
 	"runtime"
 	"time"
@@ -31,6 +32,7 @@ import (
 type ClientSettings struct {
 	TraversalSource   string
 	TransporterType   TransporterType
+	Serializer        SerializerType
 	LogVerbosity      LogVerbosity
 	Logger            Logger
 	Language          language.Tag
@@ -50,16 +52,49 @@ type ClientSettings struct {
 	// Initial amount of instantiated connections. Default: 1
 	InitialConcurrentConnections int
 	EnableUserAgentOnConnect     bool
+
+	// Delay before the first attempt to replace connections that were closed due to an error, and the interval at
+	// which the connection pool is checked for such connections. Reconnection is disabled if 0. Default: 1 second
+	ReconnectInitialBackoff time.Duration
+	// Maximum delay between attempts to replace connections. Default: 30 seconds
+	ReconnectMaxBackoff time.Duration
+	// Factor by which the delay grows after each failed attempt to replace a connection. Default: 2
+	ReconnectBackoffMultiplier float64
+	// Fraction of the delay by which it is randomly varied, to spread out reconnecting clients. Default: 0.2
+	ReconnectJitter float64
+
+	// Additional Gremlin Server urls to connect to alongside the url given to NewClient. Each host gets its own
+	// connection pool and requests are spread over the hosts which are up.
+	Endpoints []string
+	// Policy selecting the order in which hosts are tried for each request. Default: RoundRobinPolicy()
+	LoadBalancingPolicy LoadBalancingPolicy
+	// Interval at which hosts are health checked and hosts that are down are retried. Default: 5 seconds
+	HostRetryInterval time.Duration
+
+	// Policy by which requests that opted in to retries are retried after transient failures. Retries are disabled
+	// if nil. Default: DefaultRetryPolicy()
+	RetryPolicy *RetryPolicy
+
+	// Interceptors wrapping the sending of every request, the first one being the outermost. Default: nil
+	Interceptors []Interceptor
+
+	// Whether Traverser and BulkSet results are returned as they are read instead of repeating each value as often as
+	// its bulk. Default: false
+	PreserveBulk bool
 }
 
 // Client is used to connect and interact with a Gremlin-supported server.
 type Client struct {
-	url             string
-	traversalSource string
-	logHandler      *logHandler
-	transporterType TransporterType
-	connections     connectionPool
-	session         string
+	url                     string
+	traversalSource         string
+	logHandler              *logHandler
+	transporterType         TransporterType
+	connections             connectionPool
+	session                 string
+	retryPolicy             *RetryPolicy
+	interceptors            []Interceptor
+	scriptOnly              bool
+	scriptOnlyMaxParameters int
 }
 
 // NewClient creates a Client and configures it with the given parameters. During creation of the Client, a connection
@@ -69,6 +104,7 @@ func NewClient(url string, configurations ...func(settings *ClientSettings)) (*C
 	settings := &ClientSettings{
 		TraversalSource:          "g",
 		TransporterType:          Gorilla,
+		Serializer:               GraphBinaryV1,
 		LogVerbosity:             Info,
 		Logger:                   &defaultLogger{},
 		Language:                 language.English,
@@ -88,12 +124,24 @@ func NewClient(url string, configurations ...func(settings *ClientSettings)) (*C
 		NewConnectionThreshold:       defaultNewConnectionThreshold,
 		MaximumConcurrentConnections: runtime.NumCPU(),
 		InitialConcurrentConnections: defaultInitialConcurrentConnections,
+
+		ReconnectInitialBackoff:    defaultReconnectInitialBackoff,
+		ReconnectMaxBackoff:        defaultReconnectMaxBackoff,
+		ReconnectBackoffMultiplier: defaultReconnectBackoffMultiplier,
+		ReconnectJitter:            defaultReconnectJitter,
+
+		LoadBalancingPolicy: RoundRobinPolicy(),
+		HostRetryInterval:   defaultHostRetryInterval,
+
+		RetryPolicy: DefaultRetryPolicy(),
 	}
 	for _, configuration := range configurations {
 		configuration(settings)
 	}
 
 	connSettings := &connectionSettings{
+		transporterType:          settings.TransporterType,
+		serializerType:           settings.Serializer,
 		authInfo:                 settings.AuthInfo,
 		tlsConfig:                settings.TlsConfig,
 		keepAliveInterval:        settings.KeepAliveInterval,
@@ -103,6 +151,7 @@ func NewClient(url string, configurations ...func(settings *ClientSettings)) (*C
 		readBufferSize:           settings.ReadBufferSize,
 		writeBufferSize:          settings.WriteBufferSize,
 		enableUserAgentOnConnect: settings.EnableUserAgentOnConnect,
+		preserveBulk:             settings.PreserveBulk,
 	}
 
 	logHandler := newLogHandler(settings.Logger, settings.LogVerbosity, settings.Language)
@@ -112,8 +161,23 @@ func NewClient(url string, configurations ...func(settings *ClientSettings)) (*C
 			settings.MaximumConcurrentConnections, settings.MaximumConcurrentConnections)
 		settings.InitialConcurrentConnections = settings.MaximumConcurrentConnections
 	}
-	pool, err := newLoadBalancingPool(url, logHandler, connSettings, settings.NewConnectionThreshold,
-		settings.MaximumConcurrentConnections, settings.InitialConcurrentConnections)
+	newPool := func(url string) (connectionPool, error) {
+		return newLoadBalancingPool(url, logHandler, connSettings, settings.NewConnectionThreshold,
+			settings.MaximumConcurrentConnections, settings.InitialConcurrentConnections, &backoff{
+				initial:    settings.ReconnectInitialBackoff,
+				max:        settings.ReconnectMaxBackoff,
+				multiplier: settings.ReconnectBackoffMultiplier,
+				jitter:     settings.ReconnectJitter,
+			})
+	}
+	var pool connectionPool
+	var err error
+	if len(settings.Endpoints) == 0 {
+		pool, err = newPool(url)
+	} else {
+		pool, err = newClusterPool(append([]string{url}, settings.Endpoints...), settings.LoadBalancingPolicy,
+			settings.HostRetryInterval, logHandler, newPool)
+	}
 	if err != nil {
 		if err != nil {
 			logHandler.logf(Error, logErrorGeneric, "NewClient", err.Error())
@@ -128,6 +192,8 @@ func NewClient(url string, configurations ...func(settings *ClientSettings)) (*C
 		transporterType: settings.TransporterType,
 		connections:     pool,
 		session:         "",
+		retryPolicy:     settings.RetryPolicy,
+		interceptors:    settings.Interceptors,
 	}
 
 	return client, nil
@@ -150,36 +216,134 @@ func (client *Client) Close() {
 
 // SubmitWithOptions submits a Gremlin script to the server with specified RequestOptions and returns a ResultSet.
 func (client *Client) SubmitWithOptions(traversalString string, requestOptions RequestOptions) (ResultSet, error) {
+	return client.SubmitWithOptionsContext(context.Background(), traversalString, requestOptions)
+}
+
+// SubmitWithOptionsContext submits a Gremlin script to the server with specified RequestOptions and returns a
+// ResultSet. If ctx is done before all results are received, the ResultSet is cancelled and returns ctx.Err().
+func (client *Client) SubmitWithOptionsContext(ctx context.Context, traversalString string, requestOptions RequestOptions) (ResultSet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	client.logHandler.logf(Debug, submitStartedString, traversalString)
-	request := makeStringRequest(traversalString, client.traversalSource, client.session, requestOptions)
-	result, err := client.connections.write(&request)
+	result, err := client.write(ctx, func() request {
+		return makeStringRequest(traversalString, client.traversalSource, client.session, requestOptions)
+	}, requestOptions.retry)
 	if err != nil {
 		client.logHandler.logf(Error, logErrorGeneric, "Client.Submit()", err.Error())
+		return result, err
 	}
-	return result, err
+	result.bindContext(ctx)
+	return result, nil
 }
 
 // Submit submits a Gremlin script to the server and returns a ResultSet. Submit can optionally accept a map of bindings
 // to be applied to the traversalString, it is preferred however to instead wrap any bindings into a RequestOptions
 // struct and use SubmitWithOptions().
 func (client *Client) Submit(traversalString string, bindings ...map[string]interface{}) (ResultSet, error) {
+	return client.SubmitWithContext(context.Background(), traversalString, bindings...)
+}
+
+// SubmitWithContext submits a Gremlin script to the server and returns a ResultSet that is cancelled once ctx is done.
+// Bindings are handled as in Submit().
+func (client *Client) SubmitWithContext(ctx context.Context, traversalString string, bindings ...map[string]interface{}) (ResultSet, error) {
 	requestOptionsBuilder := new(RequestOptionsBuilder)
 	if len(bindings) > 0 {
 		requestOptionsBuilder.SetBindings(bindings[0])
 	}
-	return client.SubmitWithOptions(traversalString, requestOptionsBuilder.Create())
+	return client.SubmitWithOptionsContext(ctx, traversalString, requestOptionsBuilder.Create())
 }
 
 // submitBytecode submits Bytecode to the server to execute and returns a ResultSet.
 func (client *Client) submitBytecode(bytecode *Bytecode) (ResultSet, error) {
+	return client.submitBytecodeContext(context.Background(), bytecode)
+}
+
+// submitBytecodeContext submits Bytecode to the server to execute and returns a ResultSet that is cancelled once ctx
+// is done.
+func (client *Client) submitBytecodeContext(ctx context.Context, bytecode *Bytecode) (ResultSet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	client.logHandler.logf(Debug, submitStartedBytecode, *bytecode)
-	request := makeBytecodeRequest(bytecode, client.traversalSource, client.session)
-	return client.connections.write(&request)
+	makeRequest := func() request {
+		return makeBytecodeRequest(bytecode, client.traversalSource, client.session)
+	}
+	if client.scriptOnly {
+		script, bindings, err := NewTranslator("g", GremlinGroovy).translateParameterized(bytecode,
+			client.scriptOnlyMaxParameters)
+		if err != nil {
+			return nil, err
+		}
+		makeRequest = func() request {
+			return makeScriptRequest(script, bindings, bytecode, client.traversalSource, client.session)
+		}
+	}
+	result, err := client.write(ctx, makeRequest, isRetryableBytecode(bytecode))
+	if err != nil {
+		return result, err
+	}
+	result.bindContext(ctx)
+	return result, nil
+}
+
+// write sends the request created by makeRequest. If retry is set, the request is created and sent again according to
+// the RetryPolicy as long as it fails before any result is received. Requests in a session are never retried.
+func (client *Client) write(ctx context.Context, makeRequest func() request, retry bool) (ResultSet, error) {
+	policy := client.retryPolicy
+	if !retry || policy == nil || policy.MaxAttempts <= 1 || client.session != "" {
+		return client.send(ctx, makeRequest())
+	}
+
+	backoff := policy.backoff()
+	for attempt := 1; ; attempt++ {
+		resultSet, err := client.send(ctx, makeRequest())
+		if err == nil {
+			// Wait for the first response, as the request cannot be retried once results are received.
+			var empty bool
+			empty, err = resultSet.isEmptyContext(ctx)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if !empty || resultSet.GetError() == nil {
+				return resultSet, nil
+			}
+			err = resultSet.GetError()
+		}
+		if attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			if resultSet != nil {
+				// The error is returned when reading the ResultSet, as without retries.
+				return resultSet, nil
+			}
+			return nil, err
+		}
+
+		delay := backoff.delay(attempt - 1)
+		client.logHandler.logf(Warning, retryingRequest, attempt, delay, err.Error())
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// send sends the request through the interceptors to the connection pool.
+func (client *Client) send(ctx context.Context, request request) (ResultSet, error) {
+	if len(client.interceptors) == 0 {
+		return client.connections.write(&request)
+	}
+	return chainInterceptors(client.interceptors, client.invoke)(ctx, request.exported())
+}
+
+// invoke is the Invoker which ends the chain of interceptors by writing the request to the connection pool.
+func (client *Client) invoke(_ context.Context, request *Request) (ResultSet, error) {
+	internal := request.internal()
+	return client.connections.write(&internal)
 }
 
 func (client *Client) closeSession() error {
-	message := makeCloseSessionRequest(client.session)
-	result, err := client.connections.write(&message)
+	result, err := client.send(context.Background(), makeCloseSessionRequest(client.session))
 	if err != nil {
 		return err
 	}
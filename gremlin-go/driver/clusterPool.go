@@ -0,0 +1,263 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultHostRetryInterval = 5 * time.Second
+
+// clusterHost is a Gremlin Server endpoint with its own connection pool. The pool is nil while the host is down.
+type clusterHost struct {
+	url  string
+	pool connectionPool
+}
+
+// clusterPool keeps a connection pool per host and routes every request to the hosts chosen by a LoadBalancingPolicy,
+// failing over to the next host if a write fails. A host whose write or health check fails is marked down and its
+// pool is closed. A background loop health checks the hosts that are up and tries to reconnect to the hosts that are
+// down every retryInterval.
+type clusterPool struct {
+	hosts         []*clusterHost
+	policy        LoadBalancingPolicy
+	retryInterval time.Duration
+	logHandler    *logHandler
+	newPool       func(url string) (connectionPool, error)
+	lock          sync.Mutex
+	isClosed      bool
+	done          chan struct{}
+}
+
+func (pool *clusterPool) write(request *request) (ResultSet, error) {
+	pool.lock.Lock()
+	if pool.isClosed {
+		pool.lock.Unlock()
+		return nil, newError(E0103ConnectionPoolClosedError)
+	}
+	pools := make(map[string]connectionPool, len(pool.hosts))
+	up := make([]Host, 0, len(pool.hosts))
+	for _, host := range pool.hosts {
+		if host.pool != nil {
+			pools[host.url] = host.pool
+			up = append(up, Host{URL: host.url})
+		}
+	}
+	pool.lock.Unlock()
+
+	// In-flight counts are gathered without holding the cluster lock, as they require locking the host pools.
+	for i := range up {
+		up[i].InFlight = pools[up[i].URL].activeResults()
+	}
+
+	var lastErr error
+	for _, host := range pool.policy.Select(up) {
+		hostPool, ok := pools[host.URL]
+		if !ok {
+			continue
+		}
+		resultSet, err := hostPool.write(request)
+		if err == nil {
+			return resultSet, nil
+		}
+		if !isConnectionError(err) {
+			// The request itself failed, such as when it cannot be serialized, so other hosts would fail it as well.
+			return nil, err
+		}
+		lastErr = err
+		pool.markDown(host.URL, hostPool, err)
+	}
+	if lastErr == nil {
+		return nil, newError(E0106ConnectionPoolNoHostAvailableError, "all hosts are down")
+	}
+	return nil, newError(E0106ConnectionPoolNoHostAvailableError, lastErr)
+}
+
+// markDown closes the pool of the host, unless it has been replaced in the meantime.
+func (pool *clusterPool) markDown(url string, hostPool connectionPool, err error) {
+	pool.lock.Lock()
+	marked := false
+	for _, host := range pool.hosts {
+		if host.url == url && host.pool == hostPool {
+			host.pool = nil
+			marked = true
+		}
+	}
+	pool.lock.Unlock()
+
+	if marked {
+		pool.logHandler.logf(Warning, hostMarkedDown, url, err.Error())
+		hostPool.close()
+	}
+}
+
+func (pool *clusterPool) close() {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if !pool.isClosed {
+		for _, host := range pool.hosts {
+			if host.pool != nil {
+				host.pool.close()
+				host.pool = nil
+			}
+		}
+		pool.isClosed = true
+		close(pool.done)
+	}
+}
+
+func (pool *clusterPool) activeResults() int {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	count := 0
+	for _, host := range pool.hosts {
+		if host.pool != nil {
+			count += host.pool.activeResults()
+		}
+	}
+	return count
+}
+
+func (pool *clusterPool) healthy() bool {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	for _, host := range pool.hosts {
+		if host.pool != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHosts marks hosts that fail their health check down and reconnects to hosts that are down.
+func (pool *clusterPool) checkHosts() {
+	pool.lock.Lock()
+	hosts := make([]clusterHost, 0, len(pool.hosts))
+	for _, host := range pool.hosts {
+		hosts = append(hosts, *host)
+	}
+	pool.lock.Unlock()
+
+	for _, host := range hosts {
+		if host.pool != nil {
+			if !host.pool.healthy() {
+				pool.markDown(host.url, host.pool, newError(E0106ConnectionPoolNoHostAvailableError, "health check failed"))
+			}
+			continue
+		}
+
+		hostPool, err := pool.newPool(host.url)
+		if err != nil {
+			pool.logHandler.logf(Debug, hostStillDown, host.url, err.Error())
+			continue
+		}
+		pool.lock.Lock()
+		if pool.isClosed {
+			pool.lock.Unlock()
+			hostPool.close()
+			return
+		}
+		for _, h := range pool.hosts {
+			if h.url == host.url && h.pool == nil {
+				h.pool = hostPool
+				hostPool = nil
+			}
+		}
+		pool.lock.Unlock()
+		if hostPool != nil {
+			// The host was brought up concurrently.
+			hostPool.close()
+		} else {
+			pool.logHandler.logf(Info, hostMarkedUp, host.url)
+		}
+	}
+}
+
+func (pool *clusterPool) checkHostsLoop() {
+	ticker := time.NewTicker(pool.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pool.done:
+			return
+		case <-ticker.C:
+			pool.checkHosts()
+		}
+	}
+}
+
+// newClusterPool creates a pool for each of the given urls using newPool. Hosts which cannot be connected to are
+// marked down. An error is returned only if no host can be connected to.
+func newClusterPool(urls []string, policy LoadBalancingPolicy, retryInterval time.Duration, logHandler *logHandler,
+	newPool func(url string) (connectionPool, error)) (connectionPool, error) {
+	hosts := make([]*clusterHost, len(urls))
+	errorList := make([]error, len(urls))
+	var wg sync.WaitGroup
+	wg.Add(len(urls))
+	for i, url := range urls {
+		hosts[i] = &clusterHost{url: url}
+		go func(host *clusterHost, i int) {
+			defer wg.Done()
+			hostPool, err := newPool(host.url)
+			if err != nil {
+				logHandler.logf(Warning, hostMarkedDown, host.url, err.Error())
+				errorList[i] = err
+				return
+			}
+			host.pool = hostPool
+		}(hosts[i], i)
+	}
+	wg.Wait()
+
+	var firstErr error
+	up := false
+	for i, host := range hosts {
+		if host.pool != nil {
+			up = true
+		} else if firstErr == nil {
+			firstErr = errorList[i]
+		}
+	}
+	if !up {
+		return nil, newError(E0104ConnectionPoolInstantiateFail, firstErr)
+	}
+
+	if policy == nil {
+		policy = RoundRobinPolicy()
+	}
+	if retryInterval <= 0 {
+		retryInterval = defaultHostRetryInterval
+	}
+	pool := &clusterPool{
+		hosts:         hosts,
+		policy:        policy,
+		retryInterval: retryInterval,
+		logHandler:    logHandler,
+		newPool:       newPool,
+		done:          make(chan struct{}),
+	}
+	go pool.checkHostsLoop()
+	return pool, nil
+}
@@ -0,0 +1,278 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// fakeHostPool is a connectionPool which records the requests written to it.
+type fakeHostPool struct {
+	url       string
+	lock      sync.Mutex
+	writeErr  error
+	unhealthy bool
+	inFlight  int
+	written   int
+	closed    bool
+}
+
+func (pool *fakeHostPool) write(_ *request) (ResultSet, error) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	if pool.writeErr != nil {
+		return nil, pool.writeErr
+	}
+	pool.written++
+	return newChannelResultSet(pool.url, &synchronizedMap{internalMap: map[string]ResultSet{}}), nil
+}
+
+func (pool *fakeHostPool) close() {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	pool.closed = true
+}
+
+func (pool *fakeHostPool) activeResults() int {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.inFlight
+}
+
+func (pool *fakeHostPool) healthy() bool {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return !pool.unhealthy
+}
+
+func (pool *fakeHostPool) writtenCount() int {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.written
+}
+
+// fakeHostPools creates fakeHostPools on demand, failing for urls which are marked unreachable.
+type fakeHostPools struct {
+	lock        sync.Mutex
+	pools       map[string][]*fakeHostPool
+	unreachable map[string]bool
+}
+
+func newFakeHostPools(unreachable ...string) *fakeHostPools {
+	pools := &fakeHostPools{pools: map[string][]*fakeHostPool{}, unreachable: map[string]bool{}}
+	for _, url := range unreachable {
+		pools.unreachable[url] = true
+	}
+	return pools
+}
+
+func (pools *fakeHostPools) newPool(url string) (connectionPool, error) {
+	pools.lock.Lock()
+	defer pools.lock.Unlock()
+	if pools.unreachable[url] {
+		return nil, errors.New("connection refused")
+	}
+	pool := &fakeHostPool{url: url}
+	pools.pools[url] = append(pools.pools[url], pool)
+	return pool, nil
+}
+
+func (pools *fakeHostPools) setUnreachable(url string, unreachable bool) {
+	pools.lock.Lock()
+	defer pools.lock.Unlock()
+	pools.unreachable[url] = unreachable
+}
+
+func (pools *fakeHostPools) latest(url string) *fakeHostPool {
+	pools.lock.Lock()
+	defer pools.lock.Unlock()
+	created := pools.pools[url]
+	if len(created) == 0 {
+		return nil
+	}
+	return created[len(created)-1]
+}
+
+func TestClusterPool(t *testing.T) {
+	logHandler := newLogHandler(&defaultLogger{}, Error, language.English)
+	urls := []string{"ws://a", "ws://b", "ws://c"}
+
+	t.Run("newClusterPool", func(t *testing.T) {
+		t.Run("marks unreachable hosts down", func(t *testing.T) {
+			pools := newFakeHostPools("ws://b")
+			pool, err := newClusterPool(urls, nil, time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			cluster := pool.(*clusterPool)
+			assert.NotNil(t, cluster.hosts[0].pool)
+			assert.Nil(t, cluster.hosts[1].pool)
+			assert.NotNil(t, cluster.hosts[2].pool)
+			assert.True(t, pool.healthy())
+		})
+
+		t.Run("fails if no host is reachable", func(t *testing.T) {
+			pools := newFakeHostPools(urls...)
+			pool, err := newClusterPool(urls, nil, time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, pool)
+			assert.True(t, isSameErrorCode(newError(E0104ConnectionPoolInstantiateFail), err))
+		})
+	})
+
+	t.Run("write", func(t *testing.T) {
+		t.Run("spreads requests over hosts with round robin", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, RoundRobinPolicy(), time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			for i := 0; i < 9; i++ {
+				_, err := pool.write(&request{})
+				assert.Nil(t, err)
+			}
+			for _, url := range urls {
+				assert.Equal(t, 3, pools.latest(url).writtenCount())
+			}
+		})
+
+		t.Run("prefers least in flight host", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, LeastInFlightPolicy(), time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			pools.latest("ws://a").inFlight = 5
+			pools.latest("ws://b").inFlight = 1
+			pools.latest("ws://c").inFlight = 3
+			_, err = pool.write(&request{})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, pools.latest("ws://b").writtenCount())
+			assert.Equal(t, 9, pool.activeResults())
+		})
+
+		t.Run("fails over and marks failed host down", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, PrimaryWithFallbackPolicy(), time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			primary := pools.latest("ws://a")
+			primary.writeErr = &connectionError{errors.New("broken pipe")}
+			_, err = pool.write(&request{})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, pools.latest("ws://b").writtenCount())
+			assert.True(t, primary.closed)
+			assert.Nil(t, pool.(*clusterPool).hosts[0].pool)
+		})
+
+		t.Run("returns request errors without failing over", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, PrimaryWithFallbackPolicy(), time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			primary := pools.latest("ws://a")
+			serializationErr := newError(E0407GetSerializerToWriteUnknownTypeError, "struct {}")
+			primary.writeErr = serializationErr
+			_, err = pool.write(&request{})
+			assert.Equal(t, serializationErr, err)
+			assert.False(t, primary.closed)
+			assert.NotNil(t, pool.(*clusterPool).hosts[0].pool)
+			for _, url := range urls[1:] {
+				assert.Equal(t, 0, pools.latest(url).writtenCount())
+			}
+			assert.True(t, pool.healthy())
+		})
+
+		t.Run("fails if all hosts fail", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, nil, time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			for _, url := range urls {
+				pools.latest(url).writeErr = &connectionError{errors.New("broken pipe")}
+			}
+			_, err = pool.write(&request{})
+			assert.True(t, errors.Is(err, ErrNoHostAvailable))
+			assert.Contains(t, err.Error(), "broken pipe")
+			assert.False(t, pool.healthy())
+
+			_, err = pool.write(&request{})
+			assert.True(t, isSameErrorCode(newError(E0106ConnectionPoolNoHostAvailableError), err))
+		})
+
+		t.Run("fails after close", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, nil, time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			pool.close()
+
+			_, err = pool.write(&request{})
+			assert.True(t, isSameErrorCode(newError(E0103ConnectionPoolClosedError), err))
+			for _, url := range urls {
+				assert.True(t, pools.latest(url).closed)
+			}
+		})
+	})
+
+	t.Run("checkHosts", func(t *testing.T) {
+		t.Run("marks unhealthy hosts down and retries them", func(t *testing.T) {
+			pools := newFakeHostPools()
+			pool, err := newClusterPool(urls, nil, time.Hour, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+			cluster := pool.(*clusterPool)
+
+			unhealthy := pools.latest("ws://c")
+			unhealthy.unhealthy = true
+			pools.setUnreachable("ws://c", true)
+			cluster.checkHosts()
+			assert.True(t, unhealthy.closed)
+			assert.Nil(t, cluster.hosts[2].pool)
+
+			cluster.checkHosts()
+			assert.Nil(t, cluster.hosts[2].pool)
+
+			pools.setUnreachable("ws://c", false)
+			cluster.checkHosts()
+			assert.NotNil(t, cluster.hosts[2].pool)
+			assert.NotEqual(t, unhealthy, pools.latest("ws://c"))
+		})
+
+		t.Run("runs in the background", func(t *testing.T) {
+			pools := newFakeHostPools("ws://b")
+			pool, err := newClusterPool(urls, nil, 10*time.Millisecond, logHandler, pools.newPool)
+			assert.Nil(t, err)
+			defer pool.close()
+
+			pools.setUnreachable("ws://b", false)
+			assert.Eventually(t, func() bool {
+				return pools.latest("ws://b") != nil
+			}, time.Second, 10*time.Millisecond)
+		})
+	})
+}
@@ -21,6 +21,7 @@ package gremlingo
 
 import (
 	"crypto/tls"
+	"errors"
 	"sync"
 	"time"
 )
@@ -39,9 +40,13 @@ type connection struct {
 	protocol   protocol
 	results    *synchronizedMap
 	state      connectionState
+	// Whether results are added to result sets without expanding their bulk.
+	preserveBulk bool
 }
 
 type connectionSettings struct {
+	transporterType          TransporterType
+	serializerType           SerializerType
 	authInfo                 AuthInfoProvider
 	tlsConfig                *tls.Config
 	keepAliveInterval        time.Duration
@@ -51,6 +56,21 @@ type connectionSettings struct {
 	readBufferSize           int
 	writeBufferSize          int
 	enableUserAgentOnConnect bool
+	preserveBulk             bool
+}
+
+func (connSettings *connectionSettings) transporterSettings() *TransporterSettings {
+	return &TransporterSettings{
+		AuthInfo:                 connSettings.authInfo,
+		TlsConfig:                connSettings.tlsConfig,
+		KeepAliveInterval:        connSettings.keepAliveInterval,
+		WriteDeadline:            connSettings.writeDeadline,
+		ConnectionTimeout:        connSettings.connectionTimeout,
+		EnableCompression:        connSettings.enableCompression,
+		ReadBufferSize:           connSettings.readBufferSize,
+		WriteBufferSize:          connSettings.writeBufferSize,
+		EnableUserAgentOnConnect: connSettings.enableUserAgentOnConnect,
+	}
 }
 
 func (connection *connection) errorCallback() {
@@ -66,7 +86,7 @@ func (connection *connection) errorCallback() {
 
 func (connection *connection) close() error {
 	if connection.state != established {
-		return newError(err0101ConnectionCloseError)
+		return newError(E0101ConnectionCloseError)
 	}
 	connection.logHandler.log(Info, closeConnection)
 	var err error
@@ -79,14 +99,19 @@ func (connection *connection) close() error {
 
 func (connection *connection) write(request *request) (ResultSet, error) {
 	if connection.state != established {
-		return nil, newError(err0102WriteConnectionClosedError)
+		return nil, newError(E0102WriteConnectionClosedError)
 	}
 	connection.logHandler.log(Debug, writeRequest)
 	requestID := request.requestID.String()
 	connection.logHandler.logf(Debug, creatingRequest, requestID)
 	resultSet := newChannelResultSet(requestID, connection.results)
+	resultSet.(*channelResultSet).preserveBulk = connection.preserveBulk
 	connection.results.store(requestID, resultSet)
-	return resultSet, connection.protocol.write(request)
+	if err := connection.protocol.write(request); err != nil {
+		connection.results.delete(requestID)
+		return nil, err
+	}
+	return resultSet, nil
 }
 
 func (connection *connection) activeResults() int {
@@ -104,11 +129,12 @@ func createConnection(url string, logHandler *logHandler, connSettings *connecti
 	conn := &connection{
 		logHandler,
 		nil,
-		&synchronizedMap{map[string]ResultSet{}, sync.Mutex{}},
+		&synchronizedMap{internalMap: map[string]ResultSet{}},
 		initialized,
+		connSettings.preserveBulk,
 	}
 	logHandler.log(Info, connectConnection)
-	protocol, err := newGremlinServerWSProtocol(logHandler, Gorilla, url, connSettings, conn.results, conn.errorCallback)
+	protocol, err := newGremlinServerWSProtocol(logHandler, connSettings.transporterType, url, connSettings, conn.results, conn.errorCallback)
 	if err != nil {
 		logHandler.logf(Warning, failedConnection)
 		conn.state = closedDueToError
@@ -119,9 +145,34 @@ func createConnection(url string, logHandler *logHandler, connSettings *connecti
 	return conn, err
 }
 
+// connectionError is an error of the transport layer, after which the connection cannot be used anymore. Errors of a
+// single request, such as failing to serialize it, are not wrapped in it.
+type connectionError struct {
+	err error
+}
+
+func (err *connectionError) Error() string {
+	return err.err.Error()
+}
+
+func (err *connectionError) Unwrap() error {
+	return err.err
+}
+
+// isConnectionError reports whether err is caused by a connection, or a connection pool, being unusable rather than
+// by the request which was sent.
+func isConnectionError(err error) bool {
+	var connErr *connectionError
+	return errors.As(err, &connErr) || errors.Is(err, ErrConnectionClosed) ||
+		errors.Is(err, ErrConnectionPoolInstantiateFail) || errors.Is(err, ErrConnectionPoolFullButNoneValid) ||
+		errors.Is(err, ErrNoHostAvailable)
+}
+
 type synchronizedMap struct {
 	internalMap map[string]ResultSet
 	syncLock    sync.Mutex
+	// cancelled holds the keys of ResultSets that were removed before the server finished responding.
+	cancelled map[string]bool
 }
 
 func (s *synchronizedMap) store(key string, value ResultSet) {
@@ -142,17 +193,54 @@ func (s *synchronizedMap) delete(key string) {
 	delete(s.internalMap, key)
 }
 
+// cancel removes the ResultSet stored at key and remembers the key, so that responses that are still in flight for it
+// can be discarded.
+func (s *synchronizedMap) cancel(key string) {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+	if _, ok := s.internalMap[key]; !ok {
+		return
+	}
+	delete(s.internalMap, key)
+	if s.cancelled == nil {
+		s.cancelled = map[string]bool{}
+	}
+	s.cancelled[key] = true
+}
+
+// discard returns true if key belongs to a cancelled ResultSet. The key is forgotten once the final response for it
+// has been received.
+func (s *synchronizedMap) discard(key string, final bool) bool {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+	if !s.cancelled[key] {
+		return false
+	}
+	if final {
+		delete(s.cancelled, key)
+	}
+	return true
+}
+
 func (s *synchronizedMap) size() int {
 	s.syncLock.Lock()
 	defer s.syncLock.Unlock()
 	return len(s.internalMap)
 }
 
+// closeAll fails and closes all ResultSets. Their OnComplete callbacks are called once the lock is released, as they
+// may submit further requests.
 func (s *synchronizedMap) closeAll(err error) {
 	s.syncLock.Lock()
-	defer s.syncLock.Unlock()
+	resultSets := make([]ResultSet, 0, len(s.internalMap))
 	for _, resultSet := range s.internalMap {
 		resultSet.setError(err)
 		resultSet.unlockedClose()
+		resultSets = append(resultSets, resultSet)
+	}
+	s.cancelled = nil
+	s.syncLock.Unlock()
+	for _, resultSet := range resultSets {
+		resultSet.complete()
 	}
 }
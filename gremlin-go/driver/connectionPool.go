@@ -21,11 +21,16 @@ package gremlingo
 
 import (
 	"sync"
+	"time"
 )
 
 type connectionPool interface {
 	write(*request) (ResultSet, error)
 	close()
+	// activeResults returns the number of requests that are waiting for a response.
+	activeResults() int
+	// healthy returns true if the pool can serve requests, creating a connection if it has none.
+	healthy() bool
 }
 
 const defaultNewConnectionThreshold = 4
@@ -37,15 +42,20 @@ const defaultInitialConcurrentConnections = 1
 // which will trigger creation of a new connection if maximumConcurrentConnections has not been reached.
 // loadBalancingPool will use the least-used connection, and as a part of the process, getLeastUsedConnection(), will
 // remove any errored connections from the pool and ensure that the returned connection is usable.
+// If reconnectBackoff is set, a background loop replaces connections that were closed due to an error until the pool
+// holds initialConcurrentConnections again, waiting according to reconnectBackoff between failed attempts.
 type loadBalancingPool struct {
 	url          string
 	logHandler   *logHandler
 	connSettings *connectionSettings
 
-	newConnectionThreshold int
-	connections            []*connection
-	loadBalanceLock        sync.Mutex
-	isClosed               bool
+	newConnectionThreshold       int
+	initialConcurrentConnections int
+	connections                  []*connection
+	loadBalanceLock              sync.Mutex
+	isClosed                     bool
+	reconnectBackoff             *backoff
+	done                         chan struct{}
 }
 
 func (pool *loadBalancingPool) close() {
@@ -60,6 +70,9 @@ func (pool *loadBalancingPool) close() {
 			}
 		}
 		pool.isClosed = true
+		if pool.done != nil {
+			close(pool.done)
+		}
 	}
 }
 
@@ -68,7 +81,7 @@ func (pool *loadBalancingPool) write(request *request) (ResultSet, error) {
 	defer pool.loadBalanceLock.Unlock()
 
 	if pool.isClosed {
-		return nil, newError(err0103ConnectionPoolClosedError)
+		return nil, newError(E0103ConnectionPoolClosedError)
 	}
 
 	conn, err := pool.getLeastUsedConnection()
@@ -78,6 +91,63 @@ func (pool *loadBalancingPool) write(request *request) (ResultSet, error) {
 	return conn.write(request)
 }
 
+func (pool *loadBalancingPool) activeResults() int {
+	pool.loadBalanceLock.Lock()
+	defer pool.loadBalanceLock.Unlock()
+
+	count := 0
+	for _, connection := range pool.connections {
+		if connection.state == established {
+			count += connection.activeResults()
+		}
+	}
+	return count
+}
+
+func (pool *loadBalancingPool) healthy() bool {
+	pool.loadBalanceLock.Lock()
+	if pool.isClosed {
+		pool.loadBalanceLock.Unlock()
+		return false
+	}
+	validConnections := make([]*connection, 0, cap(pool.connections))
+	for _, connection := range pool.connections {
+		if connection.state == established {
+			pool.loadBalanceLock.Unlock()
+			return true
+		} else if connection.state == initialized {
+			validConnections = append(validConnections, connection)
+		}
+	}
+	pool.connections = validConnections
+	full := len(pool.connections) >= cap(pool.connections)
+	pool.loadBalanceLock.Unlock()
+	if full {
+		return false
+	}
+
+	// The connection is created without holding the lock so that writes are not blocked while dialing.
+	connection, err := createConnection(pool.url, pool.logHandler, pool.connSettings)
+	if err != nil {
+		pool.logHandler.logf(Warning, createConnectionError, err.Error())
+		return false
+	}
+
+	pool.loadBalanceLock.Lock()
+	if pool.isClosed || len(pool.connections) >= cap(pool.connections) {
+		isClosed := pool.isClosed
+		pool.loadBalanceLock.Unlock()
+		if err := connection.close(); err != nil {
+			pool.logHandler.logf(Warning, errorClosingConnection, err.Error())
+		}
+		// A connection was added concurrently, which is only done once it has been established.
+		return !isClosed
+	}
+	pool.connections = append(pool.connections, connection)
+	pool.loadBalanceLock.Unlock()
+	return true
+}
+
 // Not thread-safe. Should only be called by write which ensures no concurrency.
 func (pool *loadBalancingPool) getLeastUsedConnection() (*connection, error) {
 	// newConnection should only be called within getLeastUsedConnection and therefore is a lambda.
@@ -115,7 +185,7 @@ func (pool *loadBalancingPool) getLeastUsedConnection() (*connection, error) {
 		// If no valid connection is found.
 		if len(pool.connections) >= cap(pool.connections) {
 			// Return error if pool is full and no valid connection was found (should not ever happen).
-			return nil, newError(err0105ConnectionPoolFullButNoneValid)
+			return nil, newError(E0105ConnectionPoolFullButNoneValid)
 		} else {
 			// Return new connection if no valid connection was found and pool has capacity.
 			return newConnection()
@@ -136,8 +206,76 @@ func (pool *loadBalancingPool) getLeastUsedConnection() (*connection, error) {
 	}
 }
 
+// reconnectLoop periodically replenishes the pool until it is closed. While the pool is healthy it is checked every
+// initial backoff interval, after a failed attempt the wait grows according to reconnectBackoff.
+func (pool *loadBalancingPool) reconnectLoop() {
+	attempt := 0
+	timer := time.NewTimer(pool.reconnectBackoff.initial)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-pool.done:
+			return
+		case <-timer.C:
+		}
+
+		if pool.replenish() {
+			attempt = 0
+			timer.Reset(pool.reconnectBackoff.initial)
+		} else {
+			delay := pool.reconnectBackoff.delay(attempt)
+			pool.logHandler.logf(Warning, poolReconnectBackoff, delay.String())
+			attempt++
+			timer.Reset(delay)
+		}
+	}
+}
+
+// replenish removes connections that were closed due to an error and creates new ones until the pool holds at least
+// initialConcurrentConnections again. It returns false if a connection could not be created.
+func (pool *loadBalancingPool) replenish() bool {
+	pool.loadBalanceLock.Lock()
+	if pool.isClosed {
+		pool.loadBalanceLock.Unlock()
+		return true
+	}
+	validConnections := make([]*connection, 0, cap(pool.connections))
+	for _, connection := range pool.connections {
+		if connection.state == established || connection.state == initialized {
+			validConnections = append(validConnections, connection)
+		}
+	}
+	pool.connections = validConnections
+	missing := pool.initialConcurrentConnections - len(pool.connections)
+	pool.loadBalanceLock.Unlock()
+
+	// Connections are created without holding the lock so that writes are not blocked while dialing.
+	for ; missing > 0; missing-- {
+		connection, err := createConnection(pool.url, pool.logHandler, pool.connSettings)
+		if err != nil {
+			pool.logHandler.logf(Warning, createConnectionError, err.Error())
+			return false
+		}
+
+		pool.loadBalanceLock.Lock()
+		if pool.isClosed || len(pool.connections) >= cap(pool.connections) {
+			pool.loadBalanceLock.Unlock()
+			if err := connection.close(); err != nil {
+				pool.logHandler.logf(Warning, errorClosingConnection, err.Error())
+			}
+			return true
+		}
+		pool.connections = append(pool.connections, connection)
+		pool.loadBalanceLock.Unlock()
+		pool.logHandler.logf(Info, poolReconnected, pool.url)
+	}
+	return true
+}
+
 func newLoadBalancingPool(url string, logHandler *logHandler, connSettings *connectionSettings,
-	newConnectionThreshold int, maximumConcurrentConnections int, initialConcurrentConnections int) (connectionPool, error) {
+	newConnectionThreshold int, maximumConcurrentConnections int, initialConcurrentConnections int,
+	reconnectBackoff *backoff) (connectionPool, error) {
 	var wg sync.WaitGroup
 	wg.Add(initialConcurrentConnections)
 	var appendLock sync.Mutex
@@ -163,13 +301,20 @@ func newLoadBalancingPool(url string, logHandler *logHandler, connSettings *conn
 	wg.Wait()
 	if len(pool) == 0 && len(errorList) != 0 {
 		// If all instantiation fails return the first error's details.
-		return nil, newError(err0104ConnectionPoolInstantiateFail, errorList[0].Error())
-	}
-	return &loadBalancingPool{
-		url:                    url,
-		logHandler:             logHandler,
-		connSettings:           connSettings,
-		newConnectionThreshold: newConnectionThreshold,
-		connections:            pool,
-	}, nil
+		return nil, newError(E0104ConnectionPoolInstantiateFail, errorList[0])
+	}
+	lbp := &loadBalancingPool{
+		url:                          url,
+		logHandler:                   logHandler,
+		connSettings:                 connSettings,
+		newConnectionThreshold:       newConnectionThreshold,
+		initialConcurrentConnections: initialConcurrentConnections,
+		connections:                  pool,
+		reconnectBackoff:             reconnectBackoff,
+		done:                         make(chan struct{}),
+	}
+	if reconnectBackoff != nil && reconnectBackoff.initial > 0 {
+		go lbp.reconnectLoop()
+	}
+	return lbp, nil
 }
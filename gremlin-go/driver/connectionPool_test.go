@@ -20,11 +20,17 @@ under the License.
 package gremlingo
 
 import (
-	"github.com/stretchr/testify/assert"
-	"golang.org/x/text/language"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
 )
 
 // Arbitrarily high value to use to not trigger creation of new connections
@@ -55,6 +61,23 @@ func getMockConnection() *connection {
 	}
 }
 
+// newIdleWebsocketServer starts a server that accepts websocket connections and ignores everything sent to it.
+func newIdleWebsocketServer() *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+}
+
 func TestConnectionPool(t *testing.T) {
 	t.Run("loadBalancingPool", func(t *testing.T) {
 		smallMap := make(map[string]ResultSet)
@@ -130,5 +153,92 @@ func TestConnectionPool(t *testing.T) {
 			assert.Equal(t, closed, openConn1.state)
 			assert.Equal(t, closed, openConn2.state)
 		})
+
+		t.Run("healthy dials without holding the lock", func(t *testing.T) {
+			dialed := make(chan struct{})
+			release := make(chan struct{})
+			upgrader := websocket.Upgrader{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(dialed)
+				<-release
+				conn, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}))
+			defer server.Close()
+
+			pool := getPoolForTesting()
+			defer pool.close()
+			pool.url = "ws" + strings.TrimPrefix(server.URL, "http")
+			pool.connections = make([]*connection, 0, 4)
+
+			healthy := make(chan bool)
+			go func() {
+				healthy <- pool.healthy()
+			}()
+			<-dialed
+			assert.True(t, pool.loadBalanceLock.TryLock())
+			pool.loadBalanceLock.Unlock()
+			close(release)
+			assert.True(t, <-healthy)
+			assert.Len(t, pool.connections, 1)
+		})
+
+		t.Run("replenish", func(t *testing.T) {
+			server := newIdleWebsocketServer()
+			defer server.Close()
+
+			t.Run("restores initial connections", func(t *testing.T) {
+				pool := getPoolForTesting()
+				defer pool.close()
+				pool.url = "ws" + strings.TrimPrefix(server.URL, "http")
+				pool.initialConcurrentConnections = 2
+				pool.connections = make([]*connection, 0, 4)
+				errored := getMockConnection()
+				errored.state = closedDueToError
+				pool.connections = append(pool.connections, errored)
+
+				assert.True(t, pool.replenish())
+				assert.Len(t, pool.connections, 2)
+				for _, connection := range pool.connections {
+					assert.Equal(t, established, connection.state)
+				}
+			})
+
+			t.Run("fails for unreachable server", func(t *testing.T) {
+				pool := getPoolForTesting()
+				defer pool.close()
+				pool.url = validHostInvalidPortValidPath
+				pool.initialConcurrentConnections = 1
+				pool.connections = make([]*connection, 0, 4)
+
+				assert.False(t, pool.replenish())
+				assert.Len(t, pool.connections, 0)
+			})
+
+			t.Run("reconnect loop replaces errored connections", func(t *testing.T) {
+				pool := getPoolForTesting()
+				pool.url = "ws" + strings.TrimPrefix(server.URL, "http")
+				pool.initialConcurrentConnections = 1
+				pool.connections = make([]*connection, 0, 4)
+				pool.reconnectBackoff = &backoff{initial: 10 * time.Millisecond, max: 10 * time.Millisecond, multiplier: 1}
+				pool.done = make(chan struct{})
+				go pool.reconnectLoop()
+				defer pool.close()
+
+				assert.Eventually(t, func() bool {
+					pool.loadBalanceLock.Lock()
+					defer pool.loadBalanceLock.Unlock()
+					return len(pool.connections) == 1 && pool.connections[0].state == established
+				}, 5*time.Second, 10*time.Millisecond)
+			})
+		})
 	})
 }
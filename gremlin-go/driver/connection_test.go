@@ -21,6 +21,7 @@ package gremlingo
 
 import (
 	"crypto/tls"
+	"errors"
 	"math/big"
 	"os"
 	"reflect"
@@ -57,6 +58,8 @@ var testNames = []string{"Lyndon", "Yang", "Simon", "Rithin", "Alexey", "Valenty
 
 func newDefaultConnectionSettings() *connectionSettings {
 	return &connectionSettings{
+		transporterType:          Gorilla,
+		serializerType:           GraphBinaryV1,
 		authInfo:                 &AuthInfo{},
 		tlsConfig:                &tls.Config{},
 		keepAliveInterval:        keepAliveIntervalDefault,
@@ -222,7 +225,7 @@ func readWithNextAndHasNext(t *testing.T, g *GraphTraversalSource) {
 	// Check for Next error when no more elements left
 	res, err := traversal.Next()
 	assert.Nil(t, res)
-	assert.Equal(t, newError(err0903NextNoResultsLeftError), err)
+	assert.Equal(t, newError(E0903NextNoResultsLeftError), err)
 	assert.True(t, sortAndCompareTwoStringSlices(names, testNames))
 }
 
@@ -420,10 +423,10 @@ func TestConnection(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, closed, connection.state)
 		err = connection.close()
-		assert.Equal(t, newError(err0101ConnectionCloseError), err)
+		assert.Equal(t, newError(E0101ConnectionCloseError), err)
 		assert.Equal(t, closed, connection.state)
 		err = connection.close()
-		assert.Equal(t, newError(err0101ConnectionCloseError), err)
+		assert.Equal(t, newError(E0101ConnectionCloseError), err)
 		assert.Equal(t, closed, connection.state)
 	})
 
@@ -439,7 +442,7 @@ func TestConnection(t *testing.T) {
 		request := makeStringRequest("g.V().count()", "g", "", *new(RequestOptions))
 		resultSet, err := connection.write(&request)
 		assert.Nil(t, resultSet)
-		assert.Equal(t, newError(err0102WriteConnectionClosedError), err)
+		assert.Equal(t, newError(E0102WriteConnectionClosedError), err)
 		assert.Equal(t, closed, connection.state)
 	})
 
@@ -462,7 +465,7 @@ func TestConnection(t *testing.T) {
 		skipTestsIfNotEnabled(t, integrationTestSuiteName, testNoAuthEnable)
 		newPoolSize := 2
 		pool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info, language.English),
-			newDefaultConnectionSettings(), 4, 4, newPoolSize)
+			newDefaultConnectionSettings(), 4, 4, newPoolSize, nil)
 		assert.Nil(t, err)
 		defer pool.close()
 		assert.Len(t, pool.(*loadBalancingPool).connections, newPoolSize)
@@ -472,7 +475,7 @@ func TestConnection(t *testing.T) {
 		newPoolSize := 0
 		skipTestsIfNotEnabled(t, integrationTestSuiteName, testNoAuthEnable)
 		pool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info, language.English),
-			newDefaultConnectionSettings(), 4, 4, newPoolSize)
+			newDefaultConnectionSettings(), 4, 4, newPoolSize, nil)
 		assert.Nil(t, err)
 		defer pool.close()
 		lhp := pool.(*loadBalancingPool)
@@ -499,7 +502,7 @@ func TestConnection(t *testing.T) {
 		t.Run("pool is empty", func(t *testing.T) {
 			pool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info, language.English),
 				newDefaultConnectionSettings(),
-				newConnectionThreshold, maximumConcurrentConnections, 0)
+				newConnectionThreshold, maximumConcurrentConnections, 0, nil)
 			assert.Nil(t, err)
 			lbp := pool.(*loadBalancingPool)
 			defer lbp.close()
@@ -512,7 +515,7 @@ func TestConnection(t *testing.T) {
 		t.Run("newConcurrentThreshold reached with capacity remaining", func(t *testing.T) {
 			pool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info, language.English),
 				newDefaultConnectionSettings(),
-				newConnectionThreshold, maximumConcurrentConnections, 0)
+				newConnectionThreshold, maximumConcurrentConnections, 0, nil)
 			assert.Nil(t, err)
 			lbp := pool.(*loadBalancingPool)
 			defer lbp.close()
@@ -541,7 +544,7 @@ func TestConnection(t *testing.T) {
 		t.Run("newConcurrentThreshold reached with no capacity remaining", func(t *testing.T) {
 			capacityFullConnectionPool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info,
 				language.English), newDefaultConnectionSettings(),
-				1, 1, 1)
+				1, 1, 1, nil)
 			assert.Nil(t, err)
 			assert.NotNil(t, capacityFullConnectionPool)
 			capacityFullLbp := capacityFullConnectionPool.(*loadBalancingPool)
@@ -557,7 +560,7 @@ func TestConnection(t *testing.T) {
 		t.Run("all connections in pool invalid", func(t *testing.T) {
 			pool, err := newLoadBalancingPool(testNoAuthUrl, newLogHandler(&defaultLogger{}, Info, language.English),
 				newDefaultConnectionSettings(),
-				newConnectionThreshold, maximumConcurrentConnections, 0)
+				newConnectionThreshold, maximumConcurrentConnections, 0, nil)
 			assert.Nil(t, err)
 			lbp := pool.(*loadBalancingPool)
 			defer lbp.close()
@@ -793,7 +796,7 @@ func TestConnection(t *testing.T) {
 		anonTrav := T__.Unfold().HasLabel(testLabel)
 		slice, err := anonTrav.ToList()
 		assert.Nil(t, slice)
-		assert.Equal(t, newError(err0901ToListAnonTraversalError), err)
+		assert.Equal(t, newError(E0901ToListAnonTraversalError), err)
 	})
 
 	t.Run("Test Traversal.Iterate fail", func(t *testing.T) {
@@ -801,7 +804,7 @@ func TestConnection(t *testing.T) {
 		channel := anonTrav.Iterate()
 		assert.NotNil(t, channel)
 		err := <-channel
-		assert.Equal(t, newError(err0902IterateAnonTraversalError), err)
+		assert.Equal(t, newError(E0902IterateAnonTraversalError), err)
 	})
 
 	t.Run("Test DriverRemoteConnection with basic authentication", func(t *testing.T) {
@@ -1015,7 +1018,7 @@ func TestConnection(t *testing.T) {
 			defer s1.Close()
 			s2, err := s1.CreateSession()
 			assert.Nil(t, s2)
-			assert.Equal(t, newError(err0202CreateSessionFromSessionError), err)
+			assert.Equal(t, newError(E0202CreateSessionFromSessionError), err)
 		})
 
 		t.Run("Test CreateSession with multiple UUIDs failure", func(t *testing.T) {
@@ -1030,7 +1033,7 @@ func TestConnection(t *testing.T) {
 			defer remote.Close()
 			s1, err := remote.CreateSession(uuid.New().String(), uuid.New().String())
 			assert.Nil(t, s1)
-			assert.Equal(t, newError(err0201CreateSessionMultipleIdsError), err)
+			assert.Equal(t, newError(E0201CreateSessionMultipleIdsError), err)
 		})
 	})
 
@@ -1095,7 +1098,9 @@ func TestConnection(t *testing.T) {
 		// Add vertices and edges to graph.
 		rs, err := g.AddV("person").Property("id", T__.Unfold().Property().AddV()).ToList()
 		assert.Nil(t, rs)
-		assert.True(t, isSameErrorCode(newError(err0502ResponseHandlerReadLoopError), err))
+		assert.True(t, isSameErrorCode(newError(E0502ResponseHandlerReadLoopError), err))
+		var responseError *ResponseError
+		assert.True(t, errors.As(err, &responseError))
 
 		rs, err = g.V().Count().ToList()
 		assert.NotNil(t, rs)
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"sync"
+)
+
+// CustomTypeWriter returns the custom type info and the blob which represent a value of a custom type.
+type CustomTypeWriter func(value interface{}) (typeInfo []byte, blob []byte, err error)
+
+// CustomTypeReader returns the value represented by the custom type info and the blob of a custom type.
+type CustomTypeReader func(typeInfo []byte, blob []byte) (interface{}, error)
+
+// CustomValue is a value of a GraphBinary custom type for which no reader is registered. It is written back to the
+// server unchanged.
+type CustomValue struct {
+	Name     string
+	TypeInfo []byte
+	Blob     []byte
+}
+
+type customTypeSerializer struct {
+	name   string
+	writer CustomTypeWriter
+	reader CustomTypeReader
+}
+
+var customTypes = struct {
+	sync.RWMutex
+	byType map[reflect.Type]*customTypeSerializer
+	byName map[string]*customTypeSerializer
+}{byType: map[reflect.Type]*customTypeSerializer{}, byName: map[string]*customTypeSerializer{}}
+
+// RegisterCustomType registers the writer and reader of a GraphBinary custom type, such as a geoshape of a graph
+// provider. Values of goType are written as the custom type with the given name and values of the custom type read
+// from the server are returned by the reader. Registering a name or a type again replaces the previous registration.
+func RegisterCustomType(name string, goType reflect.Type, writer CustomTypeWriter, reader CustomTypeReader) error {
+	if name == "" || goType == nil || writer == nil || reader == nil {
+		return newError(E0409RegisterCustomTypeInvalidError)
+	}
+	customTypes.Lock()
+	defer customTypes.Unlock()
+
+	if previous, ok := customTypes.byName[name]; ok {
+		for registeredType, registered := range customTypes.byType {
+			if registered == previous {
+				delete(customTypes.byType, registeredType)
+			}
+		}
+	}
+	custom := &customTypeSerializer{name: name, writer: writer, reader: reader}
+	customTypes.byType[goType] = custom
+	customTypes.byName[name] = custom
+	return nil
+}
+
+// UnregisterCustomType removes the registration of the custom type with the given name.
+func UnregisterCustomType(name string) {
+	customTypes.Lock()
+	defer customTypes.Unlock()
+
+	custom, ok := customTypes.byName[name]
+	if !ok {
+		return
+	}
+	delete(customTypes.byName, name)
+	for registeredType, registered := range customTypes.byType {
+		if registered == custom {
+			delete(customTypes.byType, registeredType)
+		}
+	}
+}
+
+func isCustomType(value interface{}) bool {
+	if _, ok := value.(*CustomValue); ok {
+		return true
+	}
+	customTypes.RLock()
+	defer customTypes.RUnlock()
+
+	_, ok := customTypes.byType[reflect.TypeOf(value)]
+	return ok
+}
+
+// Format: {name}{custom_type_info}{value_flag}{blob}, where custom_type_info and blob are written like a ByteBuffer.
+func customTypeWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
+	var name string
+	var typeInfo, blob []byte
+	if customValue, ok := value.(*CustomValue); ok {
+		name, typeInfo, blob = customValue.Name, customValue.TypeInfo, customValue.Blob
+	} else {
+		customTypes.RLock()
+		custom, ok := customTypes.byType[reflect.TypeOf(value)]
+		customTypes.RUnlock()
+		if !ok {
+			return nil, newError(E0407GetSerializerToWriteUnknownTypeError, reflect.TypeOf(value).Name())
+		}
+		var err error
+		if typeInfo, blob, err = custom.writer(value); err != nil {
+			return nil, err
+		}
+		name = custom.name
+	}
+
+	if _, err := typeSerializer.writeValue(name, buffer, false); err != nil {
+		return nil, err
+	}
+	if err := writeCustomTypeBytes(typeInfo, buffer); err != nil {
+		return nil, err
+	}
+	typeSerializer.writeValueFlagNone(buffer)
+	if err := writeCustomTypeBytes(blob, buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeCustomTypeBytes(data []byte, buffer *bytes.Buffer) error {
+	if err := binary.Write(buffer, binary.BigEndian, int32(len(data))); err != nil {
+		return err
+	}
+	_, err := buffer.Write(data)
+	return err
+}
+
+// Reads a custom type after its type code. Values of custom types without a registered reader are returned as
+// *CustomValue.
+func readCustomType(d *graphBinaryDecoder, nullable bool) (interface{}, error) {
+	name, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	typeInfo, err := readByteBuffer(d)
+	if err != nil {
+		return nil, err
+	}
+	if nullable {
+		valueFlag, err := readByteSafe(d)
+		if err != nil {
+			return nil, err
+		}
+		if valueFlag == valueFlagNull {
+			return nil, nil
+		}
+	}
+	blob, err := readByteBuffer(d)
+	if err != nil {
+		return nil, err
+	}
+
+	customTypes.RLock()
+	custom, ok := customTypes.byName[name.(string)]
+	customTypes.RUnlock()
+	if !ok {
+		return &CustomValue{Name: name.(string), TypeInfo: typeInfo.(*ByteBuffer).Data, Blob: blob.(*ByteBuffer).Data}, nil
+	}
+	return custom.reader(typeInfo.(*ByteBuffer).Data, blob.(*ByteBuffer).Data)
+}
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+type testGeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func writeTestGeoPoint(value interface{}) ([]byte, []byte, error) {
+	point := value.(testGeoPoint)
+	return []byte("point"), []byte(fmt.Sprintf("%g,%g", point.Latitude, point.Longitude)), nil
+}
+
+func readTestGeoPoint(typeInfo []byte, blob []byte) (interface{}, error) {
+	if string(typeInfo) != "point" {
+		return nil, errors.New("unexpected type info")
+	}
+	var point testGeoPoint
+	_, err := fmt.Sscanf(string(blob), "%g,%g", &point.Latitude, &point.Longitude)
+	return point, err
+}
+
+func TestCustomType(t *testing.T) {
+	serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+	roundTrip := func(t *testing.T, value interface{}) interface{} {
+		var buffer bytes.Buffer
+		_, err := serializer.write(value, &buffer)
+		assert.Nil(t, err)
+		data := buffer.Bytes()
+		decoder := newTestDecoder(data)
+		result, err := readFullyQualifiedNullable(decoder, true)
+		assert.Nil(t, err)
+		assert.Equal(t, len(data), decoder.offset)
+		return result
+	}
+
+	t.Run("Test RegisterCustomType invalid", func(t *testing.T) {
+		err := RegisterCustomType("", reflect.TypeOf(testGeoPoint{}), writeTestGeoPoint, readTestGeoPoint)
+		assert.True(t, isSameErrorCode(err, newError(E0409RegisterCustomTypeInvalidError)))
+		err = RegisterCustomType("test.GeoPoint", nil, writeTestGeoPoint, readTestGeoPoint)
+		assert.True(t, isSameErrorCode(err, newError(E0409RegisterCustomTypeInvalidError)))
+		err = RegisterCustomType("test.GeoPoint", reflect.TypeOf(testGeoPoint{}), nil, readTestGeoPoint)
+		assert.True(t, isSameErrorCode(err, newError(E0409RegisterCustomTypeInvalidError)))
+	})
+
+	t.Run("Test registered custom type round trip", func(t *testing.T) {
+		assert.Nil(t, RegisterCustomType("test.GeoPoint", reflect.TypeOf(testGeoPoint{}), writeTestGeoPoint, readTestGeoPoint))
+		defer UnregisterCustomType("test.GeoPoint")
+
+		dataType, err := serializer.getType(testGeoPoint{})
+		assert.Nil(t, err)
+		assert.Equal(t, customType, dataType)
+
+		point := testGeoPoint{Latitude: 37.5, Longitude: -122.25}
+		assert.Equal(t, point, roundTrip(t, point))
+		assert.Equal(t, []interface{}{point, "b"}, roundTrip(t, []interface{}{point, "b"}))
+	})
+
+	t.Run("Test custom type format", func(t *testing.T) {
+		assert.Nil(t, RegisterCustomType("test.GeoPoint", reflect.TypeOf(testGeoPoint{}), writeTestGeoPoint, readTestGeoPoint))
+		defer UnregisterCustomType("test.GeoPoint")
+
+		var buffer bytes.Buffer
+		_, err := serializer.write(testGeoPoint{Latitude: 1, Longitude: 2}, &buffer)
+		assert.Nil(t, err)
+		expected := []byte{0x00, 0x00, 0x00, 0x00, 0x0d}
+		expected = append(expected, "test.GeoPoint"...)
+		expected = append(expected, 0x00, 0x00, 0x00, 0x05)
+		expected = append(expected, "point"...)
+		expected = append(expected, 0x00, 0x00, 0x00, 0x00, 0x03)
+		expected = append(expected, "1,2"...)
+		assert.Equal(t, expected, buffer.Bytes())
+	})
+
+	t.Run("Test unregistered custom type round trip", func(t *testing.T) {
+		assert.Nil(t, RegisterCustomType("test.GeoPoint", reflect.TypeOf(testGeoPoint{}), writeTestGeoPoint, readTestGeoPoint))
+		var buffer bytes.Buffer
+		_, err := serializer.write(testGeoPoint{Latitude: 1, Longitude: 2}, &buffer)
+		assert.Nil(t, err)
+		UnregisterCustomType("test.GeoPoint")
+
+		data := buffer.Bytes()
+		result, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+		assert.Nil(t, err)
+		value := &CustomValue{Name: "test.GeoPoint", TypeInfo: []byte("point"), Blob: []byte("1,2")}
+		assert.Equal(t, value, result)
+
+		_, err = serializer.getType(testGeoPoint{})
+		assert.True(t, isSameErrorCode(err, newError(E0407GetSerializerToWriteUnknownTypeError)))
+
+		assert.Equal(t, value, roundTrip(t, value))
+		buffer.Reset()
+		_, err = serializer.write(value, &buffer)
+		assert.Nil(t, err)
+		assert.Equal(t, data, buffer.Bytes())
+	})
+
+	t.Run("Test custom type null value", func(t *testing.T) {
+		data := []byte{0x00, 0x00, 0x00, 0x00, 0x01, 'x', 0x00, 0x00, 0x00, 0x00, valueFlagNull}
+		decoder := newTestDecoder(data)
+		result, err := readFullyQualifiedNullable(decoder, true)
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, len(data), decoder.offset)
+	})
+
+	t.Run("Test custom type writer error", func(t *testing.T) {
+		writeErr := errors.New("cannot write")
+		assert.Nil(t, RegisterCustomType("test.GeoPoint", reflect.TypeOf(testGeoPoint{}), func(interface{}) ([]byte, []byte, error) {
+			return nil, nil, writeErr
+		}, readTestGeoPoint))
+		defer UnregisterCustomType("test.GeoPoint")
+
+		var buffer bytes.Buffer
+		_, err := serializer.write(testGeoPoint{}, &buffer)
+		assert.Equal(t, writeErr, err)
+	})
+}
@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Char is written as the GraphBinary type Char. Chars are read as rune, which is written as an Int like any other
+// int32, so a rune must be converted to Char to be written as a Char.
+type Char rune
+
+// Instant is written as the GraphBinary type Instant, a point on the time-line. Instants are read as time.Time in UTC.
+type Instant struct {
+	time.Time
+}
+
+// OffsetDateTime is written as the GraphBinary type OffsetDateTime, a date-time with an offset from UTC. The offset
+// is the one of the location of the time.Time. OffsetDateTimes are read as time.Time with a fixed zone location.
+type OffsetDateTime struct {
+	time.Time
+}
+
+// ZonedDateTime is written as the GraphBinary type ZonedDateTime, a date-time with a time-zone, which GraphBinary
+// represents by its offset from UTC. ZonedDateTimes are read as time.Time with a fixed zone location.
+type ZonedDateTime struct {
+	time.Time
+}
+
+// LocalDate is a date without a time-zone, such as 2007-12-03.
+type LocalDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// String returns the ISO-8601 representation of the LocalDate.
+func (d LocalDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// LocalTime is a time without a time-zone, such as 10:15:30.
+type LocalTime struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// String returns the ISO-8601 representation of the LocalTime.
+func (t LocalTime) String() string {
+	if t.Nanosecond != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%09d", t.Hour, t.Minute, t.Second, t.Nanosecond)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+func (t LocalTime) nanoOfDay() int64 {
+	return int64(t.Hour)*int64(time.Hour) + int64(t.Minute)*int64(time.Minute) + int64(t.Second)*int64(time.Second) +
+		int64(t.Nanosecond)
+}
+
+func localTimeOfNanoOfDay(nanos int64) LocalTime {
+	d := time.Duration(nanos)
+	return LocalTime{Hour: int(d / time.Hour), Minute: int(d % time.Hour / time.Minute),
+		Second: int(d % time.Minute / time.Second), Nanosecond: int(d % time.Second)}
+}
+
+// LocalDateTime is a date-time without a time-zone, such as 2007-12-03T10:15:30.
+type LocalDateTime struct {
+	Date LocalDate
+	Time LocalTime
+}
+
+// String returns the ISO-8601 representation of the LocalDateTime.
+func (dt LocalDateTime) String() string {
+	return dt.Date.String() + "T" + dt.Time.String()
+}
+
+// In returns the time.Time of the LocalDateTime in the given location.
+func (dt LocalDateTime) In(loc *time.Location) time.Time {
+	return time.Date(dt.Date.Year, dt.Date.Month, dt.Date.Day, dt.Time.Hour, dt.Time.Minute, dt.Time.Second,
+		dt.Time.Nanosecond, loc)
+}
+
+// MonthDay is a month and day of month, such as --12-03.
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+// String returns the ISO-8601 representation of the MonthDay.
+func (md MonthDay) String() string {
+	return fmt.Sprintf("--%02d-%02d", int(md.Month), md.Day)
+}
+
+// OffsetTime is a time with an offset from UTC, such as 10:15:30+01:00.
+type OffsetTime struct {
+	Time   LocalTime
+	Offset ZoneOffset
+}
+
+// String returns the ISO-8601 representation of the OffsetTime.
+func (t OffsetTime) String() string {
+	return t.Time.String() + t.Offset.String()
+}
+
+// Period is a date-based amount of time, such as 2 years, 3 months and 4 days.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// String returns the ISO-8601 representation of the Period.
+func (p Period) String() string {
+	if p == (Period{}) {
+		return "P0D"
+	}
+	s := "P"
+	if p.Years != 0 {
+		s += fmt.Sprintf("%dY", p.Years)
+	}
+	if p.Months != 0 {
+		s += fmt.Sprintf("%dM", p.Months)
+	}
+	if p.Days != 0 {
+		s += fmt.Sprintf("%dD", p.Days)
+	}
+	return s
+}
+
+// Year is a year, such as 2018.
+type Year int32
+
+// YearMonth is a year and month, such as 2007-12.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// String returns the ISO-8601 representation of the YearMonth.
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, int(ym.Month))
+}
+
+// ZoneOffset is an offset from UTC in seconds, such as +02:00.
+type ZoneOffset int32
+
+// String returns the ISO-8601 representation of the ZoneOffset.
+func (z ZoneOffset) String() string {
+	if z == 0 {
+		return "Z"
+	}
+	sign := '+'
+	seconds := int(z)
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	s := fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf(":%02d", seconds%60)
+	}
+	return s
+}
+
+// Location returns a fixed zone time.Location with the offset of the ZoneOffset.
+func (z ZoneOffset) Location() *time.Location {
+	return time.FixedZone(z.String(), int(z))
+}
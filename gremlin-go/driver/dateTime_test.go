@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateTime(t *testing.T) {
+	t.Run("Test String", func(t *testing.T) {
+		localTime := LocalTime{Hour: 10, Minute: 15, Second: 30}
+		assert.Equal(t, "2007-12-03", LocalDate{Year: 2007, Month: time.December, Day: 3}.String())
+		assert.Equal(t, "10:15:30", localTime.String())
+		assert.Equal(t, "10:15:30.000000500", LocalTime{Hour: 10, Minute: 15, Second: 30, Nanosecond: 500}.String())
+		assert.Equal(t, "2007-12-03T10:15:30",
+			LocalDateTime{Date: LocalDate{Year: 2007, Month: time.December, Day: 3}, Time: localTime}.String())
+		assert.Equal(t, "--12-03", MonthDay{Month: time.December, Day: 3}.String())
+		assert.Equal(t, "10:15:30+01:00", OffsetTime{Time: localTime, Offset: 3600}.String())
+		assert.Equal(t, "P2Y3M4D", Period{Years: 2, Months: 3, Days: 4}.String())
+		assert.Equal(t, "P-1M", Period{Months: -1}.String())
+		assert.Equal(t, "P0D", Period{}.String())
+		assert.Equal(t, "2007-12", YearMonth{Year: 2007, Month: time.December}.String())
+		assert.Equal(t, "Z", ZoneOffset(0).String())
+		assert.Equal(t, "-05:30", ZoneOffset(-5*3600-30*60).String())
+		assert.Equal(t, "+00:00:45", ZoneOffset(45).String())
+	})
+
+	t.Run("Test LocalTime nano of day", func(t *testing.T) {
+		localTime := LocalTime{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999999999}
+		assert.Equal(t, int64(86399999999999), localTime.nanoOfDay())
+		assert.Equal(t, localTime, localTimeOfNanoOfDay(localTime.nanoOfDay()))
+		assert.Equal(t, LocalTime{}, localTimeOfNanoOfDay(0))
+	})
+
+	t.Run("Test LocalDateTime In", func(t *testing.T) {
+		dt := LocalDateTime{Date: LocalDate{Year: 2007, Month: time.December, Day: 3}, Time: LocalTime{Hour: 10, Minute: 15}}
+		in := dt.In(ZoneOffset(3600).Location())
+		assert.True(t, time.Date(2007, time.December, 3, 9, 15, 0, 0, time.UTC).Equal(in))
+		_, offset := in.Zone()
+		assert.Equal(t, 3600, offset)
+	})
+}
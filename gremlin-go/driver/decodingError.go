@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+// DecodingError is the error returned when a GraphBinary response cannot be deserialized, because it is truncated or
+// otherwise malformed. It can be obtained from the errors returned by the driver with errors.As.
+type DecodingError struct {
+	// Offset is the position in the message at which decoding failed.
+	Offset int
+	// TypeCode is the GraphBinary type code of the innermost value which could not be decoded.
+	TypeCode byte
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (decodingError *DecodingError) Error() string {
+	return newError(E0411ReadDecodingError, decodingError.TypeCode, decodingError.Offset, decodingError.Err).Error()
+}
+
+// Unwrap returns the cause of the DecodingError.
+func (decodingError *DecodingError) Unwrap() error {
+	return decodingError.Err
+}
@@ -20,6 +20,7 @@ under the License.
 package gremlingo
 
 import (
+	"context"
 	"crypto/tls"
 	"runtime"
 	"time"
@@ -34,6 +35,7 @@ type DriverRemoteConnectionSettings struct {
 
 	TraversalSource          string
 	TransporterType          TransporterType
+	Serializer               SerializerType
 	LogVerbosity             LogVerbosity
 	Logger                   Logger
 	Language                 language.Tag
@@ -53,6 +55,45 @@ type DriverRemoteConnectionSettings struct {
 	MaximumConcurrentConnections int
 	// Initial amount of instantiated connections. Default: 1
 	InitialConcurrentConnections int
+
+	// Delay before the first attempt to replace connections that were closed due to an error, and the interval at
+	// which the connection pool is checked for such connections. Reconnection is disabled if 0. Default: 1 second
+	ReconnectInitialBackoff time.Duration
+	// Maximum delay between attempts to replace connections. Default: 30 seconds
+	ReconnectMaxBackoff time.Duration
+	// Factor by which the delay grows after each failed attempt to replace a connection. Default: 2
+	ReconnectBackoffMultiplier float64
+	// Fraction of the delay by which it is randomly varied, to spread out reconnecting clients. Default: 0.2
+	ReconnectJitter float64
+
+	// Additional Gremlin Server urls to connect to alongside the url given to NewDriverRemoteConnection. Each host
+	// gets its own connection pool and requests are spread over the hosts which are up. Sessions are bound to the
+	// url given to NewDriverRemoteConnection.
+	Endpoints []string
+	// Policy selecting the order in which hosts are tried for each request. Default: RoundRobinPolicy()
+	LoadBalancingPolicy LoadBalancingPolicy
+	// Interval at which hosts are health checked and hosts that are down are retried. Default: 5 seconds
+	HostRetryInterval time.Duration
+
+	// Policy by which requests that opted in to retries are retried after transient failures. Retries are disabled
+	// if nil. Default: DefaultRetryPolicy()
+	RetryPolicy *RetryPolicy
+
+	// Interceptors wrapping the sending of every request, the first one being the outermost. Default: nil
+	Interceptors []Interceptor
+
+	// Whether Traverser and BulkSet results are returned as they are read instead of repeating each value as often as
+	// its bulk. Default: false
+	PreserveBulk bool
+
+	// Whether traversals are translated to parameterized Gremlin scripts and submitted as eval requests, for servers
+	// which do not accept Bytecode. Default: false
+	ScriptOnly bool
+
+	// Maximum number of bindings sent with the script of a traversal if ScriptOnly is set, including those given with
+	// Bindings. Further literals are written into the script. It must not exceed the maxParameters setting of the
+	// server. Default: 16
+	ScriptOnlyMaxParameters int
 }
 
 // DriverRemoteConnection is a remote connection.
@@ -75,6 +116,7 @@ func NewDriverRemoteConnection(
 
 		TraversalSource:          "g",
 		TransporterType:          Gorilla,
+		Serializer:               GraphBinaryV1,
 		LogVerbosity:             Info,
 		Logger:                   &defaultLogger{},
 		Language:                 language.English,
@@ -94,12 +136,26 @@ func NewDriverRemoteConnection(
 		NewConnectionThreshold:       defaultNewConnectionThreshold,
 		MaximumConcurrentConnections: runtime.NumCPU(),
 		InitialConcurrentConnections: defaultInitialConcurrentConnections,
+
+		ReconnectInitialBackoff:    defaultReconnectInitialBackoff,
+		ReconnectMaxBackoff:        defaultReconnectMaxBackoff,
+		ReconnectBackoffMultiplier: defaultReconnectBackoffMultiplier,
+		ReconnectJitter:            defaultReconnectJitter,
+
+		LoadBalancingPolicy: RoundRobinPolicy(),
+		HostRetryInterval:   defaultHostRetryInterval,
+
+		RetryPolicy: DefaultRetryPolicy(),
+
+		ScriptOnlyMaxParameters: defaultScriptOnlyMaxParameters,
 	}
 	for _, configuration := range configurations {
 		configuration(settings)
 	}
 
 	connSettings := &connectionSettings{
+		transporterType:          settings.TransporterType,
+		serializerType:           settings.Serializer,
 		authInfo:                 settings.AuthInfo,
 		tlsConfig:                settings.TlsConfig,
 		keepAliveInterval:        settings.KeepAliveInterval,
@@ -109,6 +165,7 @@ func NewDriverRemoteConnection(
 		readBufferSize:           settings.ReadBufferSize,
 		writeBufferSize:          settings.WriteBufferSize,
 		enableUserAgentOnConnect: settings.EnableUserAgentOnConnect,
+		preserveBulk:             settings.PreserveBulk,
 	}
 
 	logHandler := newLogHandler(settings.Logger, settings.LogVerbosity, settings.Language)
@@ -122,8 +179,23 @@ func NewDriverRemoteConnection(
 			settings.MaximumConcurrentConnections, settings.MaximumConcurrentConnections)
 		settings.InitialConcurrentConnections = settings.MaximumConcurrentConnections
 	}
-	pool, err := newLoadBalancingPool(url, logHandler, connSettings, settings.NewConnectionThreshold,
-		settings.MaximumConcurrentConnections, settings.InitialConcurrentConnections)
+	newPool := func(url string) (connectionPool, error) {
+		return newLoadBalancingPool(url, logHandler, connSettings, settings.NewConnectionThreshold,
+			settings.MaximumConcurrentConnections, settings.InitialConcurrentConnections, &backoff{
+				initial:    settings.ReconnectInitialBackoff,
+				max:        settings.ReconnectMaxBackoff,
+				multiplier: settings.ReconnectBackoffMultiplier,
+				jitter:     settings.ReconnectJitter,
+			})
+	}
+	var pool connectionPool
+	var err error
+	if len(settings.Endpoints) == 0 || settings.session != "" {
+		pool, err = newPool(url)
+	} else {
+		pool, err = newClusterPool(append([]string{url}, settings.Endpoints...), settings.LoadBalancingPolicy,
+			settings.HostRetryInterval, logHandler, newPool)
+	}
 	if err != nil {
 		if err != nil {
 			logHandler.logf(Error, logErrorGeneric, "NewDriverRemoteConnection", err.Error())
@@ -132,12 +204,16 @@ func NewDriverRemoteConnection(
 	}
 
 	client := &Client{
-		url:             url,
-		traversalSource: settings.TraversalSource,
-		logHandler:      logHandler,
-		transporterType: settings.TransporterType,
-		connections:     pool,
-		session:         settings.session,
+		url:                     url,
+		traversalSource:         settings.TraversalSource,
+		logHandler:              logHandler,
+		transporterType:         settings.TransporterType,
+		connections:             pool,
+		session:                 settings.session,
+		retryPolicy:             settings.RetryPolicy,
+		interceptors:            settings.Interceptors,
+		scriptOnly:              settings.ScriptOnly,
+		scriptOnlyMaxParameters: settings.ScriptOnlyMaxParameters,
 	}
 
 	return &DriverRemoteConnection{client: client, isClosed: false, settings: settings}, nil
@@ -166,7 +242,13 @@ func (driver *DriverRemoteConnection) Close() {
 
 // SubmitWithOptions sends a string traversal to the server along with specified RequestOptions.
 func (driver *DriverRemoteConnection) SubmitWithOptions(traversalString string, requestOptions RequestOptions) (ResultSet, error) {
-	result, err := driver.client.SubmitWithOptions(traversalString, requestOptions)
+	return driver.SubmitWithOptionsContext(context.Background(), traversalString, requestOptions)
+}
+
+// SubmitWithOptionsContext sends a string traversal to the server along with specified RequestOptions. The returned
+// ResultSet is cancelled once ctx is done.
+func (driver *DriverRemoteConnection) SubmitWithOptionsContext(ctx context.Context, traversalString string, requestOptions RequestOptions) (ResultSet, error) {
+	result, err := driver.client.SubmitWithOptionsContext(ctx, traversalString, requestOptions)
 	if err != nil {
 		driver.client.logHandler.logf(Error, logErrorGeneric, "Driver.Submit()", err.Error())
 	}
@@ -178,12 +260,22 @@ func (driver *DriverRemoteConnection) Submit(traversalString string) (ResultSet,
 	return driver.SubmitWithOptions(traversalString, *new(RequestOptions))
 }
 
+// SubmitWithContext sends a string traversal to the server. The returned ResultSet is cancelled once ctx is done.
+func (driver *DriverRemoteConnection) SubmitWithContext(ctx context.Context, traversalString string) (ResultSet, error) {
+	return driver.SubmitWithOptionsContext(ctx, traversalString, *new(RequestOptions))
+}
+
 // submitBytecode sends a Bytecode traversal to the server.
 func (driver *DriverRemoteConnection) submitBytecode(bytecode *Bytecode) (ResultSet, error) {
+	return driver.submitBytecodeContext(context.Background(), bytecode)
+}
+
+// submitBytecodeContext sends a Bytecode traversal to the server. The returned ResultSet is cancelled once ctx is done.
+func (driver *DriverRemoteConnection) submitBytecodeContext(ctx context.Context, bytecode *Bytecode) (ResultSet, error) {
 	if driver.isClosed {
-		return nil, newError(err0203SubmitBytecodeToClosedConnectionError)
+		return nil, newError(E0203SubmitBytecodeToClosedConnectionError)
 	}
-	return driver.client.submitBytecode(bytecode)
+	return driver.client.submitBytecodeContext(ctx, bytecode)
 }
 
 func (driver *DriverRemoteConnection) isSession() bool {
@@ -193,9 +285,9 @@ func (driver *DriverRemoteConnection) isSession() bool {
 // CreateSession generates a new session. sessionId stores the optional UUID param. It can be used to create a session with a specific UUID.
 func (driver *DriverRemoteConnection) CreateSession(sessionId ...string) (*DriverRemoteConnection, error) {
 	if len(sessionId) > 1 {
-		return nil, newError(err0201CreateSessionMultipleIdsError)
+		return nil, newError(E0201CreateSessionMultipleIdsError)
 	} else if driver.isSession() {
-		return nil, newError(err0202CreateSessionFromSessionError)
+		return nil, newError(E0202CreateSessionFromSessionError)
 	}
 
 	driver.client.logHandler.log(Info, creatingSessionConnection)
@@ -208,6 +300,7 @@ func (driver *DriverRemoteConnection) CreateSession(sessionId ...string) (*Drive
 		// copy other settings from parent
 		settings.TraversalSource = driver.settings.TraversalSource
 		settings.TransporterType = driver.settings.TransporterType
+		settings.Serializer = driver.settings.Serializer
 		settings.Logger = driver.settings.Logger
 		settings.LogVerbosity = driver.settings.LogVerbosity
 		settings.Language = driver.settings.Language
@@ -221,6 +314,17 @@ func (driver *DriverRemoteConnection) CreateSession(sessionId ...string) (*Drive
 		settings.ReadBufferSize = driver.settings.ReadBufferSize
 		settings.WriteBufferSize = driver.settings.WriteBufferSize
 		settings.MaximumConcurrentConnections = driver.settings.MaximumConcurrentConnections
+		settings.ReconnectInitialBackoff = driver.settings.ReconnectInitialBackoff
+		settings.ReconnectMaxBackoff = driver.settings.ReconnectMaxBackoff
+		settings.ReconnectBackoffMultiplier = driver.settings.ReconnectBackoffMultiplier
+		settings.ReconnectJitter = driver.settings.ReconnectJitter
+		settings.LoadBalancingPolicy = driver.settings.LoadBalancingPolicy
+		settings.HostRetryInterval = driver.settings.HostRetryInterval
+		settings.RetryPolicy = driver.settings.RetryPolicy
+		settings.Interceptors = driver.settings.Interceptors
+		settings.PreserveBulk = driver.settings.PreserveBulk
+		settings.ScriptOnly = driver.settings.ScriptOnly
+		settings.ScriptOnlyMaxParameters = driver.settings.ScriptOnlyMaxParameters
 	})
 	if err != nil {
 		return nil, err
@@ -21,6 +21,7 @@ package gremlingo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -29,74 +30,156 @@ import (
 	"golang.org/x/text/language"
 )
 
-type errorCode string
+// ErrorCode identifies an error detected by the driver, such as "E0103_CONNECTIONPOOL_CLOSED_ERROR".
+type ErrorCode string
+
+// GremlinError is the error returned for failures detected by the driver. Errors with the same Code match with
+// errors.Is, so they can be compared with the exported sentinel errors, and the error which caused a GremlinError, if
+// any, can be reached with errors.Unwrap, errors.Is and errors.As.
+type GremlinError struct {
+	// Code identifies the error.
+	Code    ErrorCode
+	message string
+	cause   error
+}
+
+func (gremlinError *GremlinError) Error() string {
+	if gremlinError.message == "" {
+		return string(gremlinError.Code)
+	}
+	return gremlinError.message
+}
+
+// Unwrap returns the error which caused the GremlinError, if any.
+func (gremlinError *GremlinError) Unwrap() error {
+	return gremlinError.cause
+}
+
+// Is reports whether target is a GremlinError with the same Code.
+func (gremlinError *GremlinError) Is(target error) bool {
+	targetError, ok := target.(*GremlinError)
+	return ok && targetError.Code == gremlinError.Code
+}
 
+// Codes of the errors detected by the driver, for comparison with GremlinError.Code.
 const (
 	// connection.go errors
-	err0101ConnectionCloseError       errorCode = "E0101_CONNECTION_CLOSE_ERROR"
-	err0102WriteConnectionClosedError errorCode = "E0102_CONNECTION_WRITE_CLOSED_ERROR"
+	E0101ConnectionCloseError       ErrorCode = "E0101_CONNECTION_CLOSE_ERROR"
+	E0102WriteConnectionClosedError ErrorCode = "E0102_CONNECTION_WRITE_CLOSED_ERROR"
 
 	// connectionPool.go errors
-	err0103ConnectionPoolClosedError      errorCode = "E0103_CONNECTIONPOOL_CLOSED_ERROR"
-	err0104ConnectionPoolInstantiateFail  errorCode = "E0104_CONNECTIONPOOL_INSTANTIATE_FAIL"
-	err0105ConnectionPoolFullButNoneValid errorCode = "E0105_CONNECTIONPOOL_FULL_NONE_VALID"
+	E0103ConnectionPoolClosedError      ErrorCode = "E0103_CONNECTIONPOOL_CLOSED_ERROR"
+	E0104ConnectionPoolInstantiateFail  ErrorCode = "E0104_CONNECTIONPOOL_INSTANTIATE_FAIL"
+	E0105ConnectionPoolFullButNoneValid ErrorCode = "E0105_CONNECTIONPOOL_FULL_NONE_VALID"
+
+	// clusterPool.go errors
+	E0106ConnectionPoolNoHostAvailableError ErrorCode = "E0106_CONNECTIONPOOL_NO_HOST_AVAILABLE"
 
 	// driverRemoteConnection.go errors
-	err0201CreateSessionMultipleIdsError         errorCode = "E0201_DRIVER_REMOTE_CONNECTION_CREATESESSION_MULTIPLE_UUIDS_ERROR"
-	err0202CreateSessionFromSessionError         errorCode = "E0202_DRIVER_REMOTE_CONNECTION_CREATESESSION_SESSION_FROM_SESSION_ERROR"
-	err0203SubmitBytecodeToClosedConnectionError errorCode = "E0203_DRIVER_REMOTE_CONNECTION_SUBMITBYTECODE_TO_CLOSED_CONNECTION_ERROR"
+	E0201CreateSessionMultipleIdsError         ErrorCode = "E0201_DRIVER_REMOTE_CONNECTION_CREATESESSION_MULTIPLE_UUIDS_ERROR"
+	E0202CreateSessionFromSessionError         ErrorCode = "E0202_DRIVER_REMOTE_CONNECTION_CREATESESSION_SESSION_FROM_SESSION_ERROR"
+	E0203SubmitBytecodeToClosedConnectionError ErrorCode = "E0203_DRIVER_REMOTE_CONNECTION_SUBMITBYTECODE_TO_CLOSED_CONNECTION_ERROR"
 
 	// graph.go errors
-	err0301GetPathObjectInvalidPathUnequalLengthsError errorCode = "E0301_GRAPH_GETPATHOBJECT_UNEQUAL_LABELS_OBJECTS_LENGTH_ERROR"
-	err0302GetPathObjectInvalidPathNonStringLabelError errorCode = "E0302_GRAPH_GETPATHOBJECT_NON_STRING_VALUE_IN_LABELS_ERROR"
-	err0303GetPathNoLabelFoundError                    errorCode = "E0303_GRAPH_GETPATHOBJECT_NO_LABEL_ERROR"
+	E0301GetPathObjectInvalidPathUnequalLengthsError ErrorCode = "E0301_GRAPH_GETPATHOBJECT_UNEQUAL_LABELS_OBJECTS_LENGTH_ERROR"
+	E0302GetPathObjectInvalidPathNonStringLabelError ErrorCode = "E0302_GRAPH_GETPATHOBJECT_NON_STRING_VALUE_IN_LABELS_ERROR"
+	E0303GetPathNoLabelFoundError                    ErrorCode = "E0303_GRAPH_GETPATHOBJECT_NO_LABEL_ERROR"
 
 	// graphBinary.go errors
-	err0401WriteTypeValueUnexpectedNullError    errorCode = "E0401_GRAPH_BINARY_WRITETYPEVALUE_UNEXPECTED_NULL_ERROR"
-	err0402BytecodeWriterError                  errorCode = "E0402_GRAPH_BINARY_WRITER_BYTECODE_ERROR"
-	err0403WriteValueUnexpectedNullError        errorCode = "E0403_GRAPH_BINARY_WRITEVALUE_UNEXPECTED_NULL_ERROR"
-	err0404ReadNullTypeError                    errorCode = "E0404_GRAPH_BINARY_READ_NULLTYPE_ERROR"
-	err0405ReadValueInvalidNullInputError       errorCode = "E0405_GRAPH_BINARY_READVALUE_NULL_INPUT_ERROR"
-	err0406EnumReaderInvalidTypeError           errorCode = "E0406_GRAPH_BINARY_ENUMREADER_INVALID_TYPE_ERROR"
-	err0407GetSerializerToWriteUnknownTypeError errorCode = "E0407_GRAPH_BINARY_GETSERIALIZERTOWRITE_UNKNOWN_TYPE_ERROR"
-	err0408GetSerializerToReadUnknownTypeError  errorCode = "E0408_GRAPH_BINARY_GETSERIALIZERTOREAD_UNKNOWN_TYPE_ERROR"
+	E0401WriteTypeValueUnexpectedNullError    ErrorCode = "E0401_GRAPH_BINARY_WRITETYPEVALUE_UNEXPECTED_NULL_ERROR"
+	E0402BytecodeWriterError                  ErrorCode = "E0402_GRAPH_BINARY_WRITER_BYTECODE_ERROR"
+	E0403WriteValueUnexpectedNullError        ErrorCode = "E0403_GRAPH_BINARY_WRITEVALUE_UNEXPECTED_NULL_ERROR"
+	E0404ReadNullTypeError                    ErrorCode = "E0404_GRAPH_BINARY_READ_NULLTYPE_ERROR"
+	E0405ReadValueInvalidNullInputError       ErrorCode = "E0405_GRAPH_BINARY_READVALUE_NULL_INPUT_ERROR"
+	E0406EnumReaderInvalidTypeError           ErrorCode = "E0406_GRAPH_BINARY_ENUMREADER_INVALID_TYPE_ERROR"
+	E0407GetSerializerToWriteUnknownTypeError ErrorCode = "E0407_GRAPH_BINARY_GETSERIALIZERTOWRITE_UNKNOWN_TYPE_ERROR"
+	E0408GetSerializerToReadUnknownTypeError  ErrorCode = "E0408_GRAPH_BINARY_GETSERIALIZERTOREAD_UNKNOWN_TYPE_ERROR"
+	E0409RegisterCustomTypeInvalidError       ErrorCode = "E0409_GRAPH_BINARY_REGISTER_CUSTOM_TYPE_INVALID_ERROR"
+	E0410WriteInvalidInetAddressError         ErrorCode = "E0410_GRAPH_BINARY_WRITE_INVALID_INET_ADDRESS_ERROR"
+	E0411ReadDecodingError                    ErrorCode = "E0411_GRAPH_BINARY_READ_DECODING_ERROR"
+	E0412ReadTruncatedDataError               ErrorCode = "E0412_GRAPH_BINARY_READ_TRUNCATED_DATA_ERROR"
+	E0413ReadInvalidLengthError               ErrorCode = "E0413_GRAPH_BINARY_READ_INVALID_LENGTH_ERROR"
+	E0414ReadUnexpectedValueError             ErrorCode = "E0414_GRAPH_BINARY_READ_UNEXPECTED_VALUE_ERROR"
 
 	// protocol.go errors
-	err0501ResponseHandlerResultSetNotCreatedError errorCode = "E0501_PROTOCOL_RESPONSEHANDLER_NO_RESULTSET_ON_DATA_RECEIVE"
-	err0502ResponseHandlerReadLoopError            errorCode = "E0502_PROTOCOL_RESPONSEHANDLER_READ_LOOP_ERROR"
-	err0503ResponseHandlerAuthError                errorCode = "E0503_PROTOCOL_RESPONSEHANDLER_AUTH_ERROR"
+	E0501ResponseHandlerResultSetNotCreatedError ErrorCode = "E0501_PROTOCOL_RESPONSEHANDLER_NO_RESULTSET_ON_DATA_RECEIVE"
+	E0502ResponseHandlerReadLoopError            ErrorCode = "E0502_PROTOCOL_RESPONSEHANDLER_READ_LOOP_ERROR"
+	E0503ResponseHandlerAuthError                ErrorCode = "E0503_PROTOCOL_RESPONSEHANDLER_AUTH_ERROR"
 
 	// result.go errors
-	err0601ResultNotVertexError         errorCode = "E0601_RESULT_NOT_VERTEX_ERROR"
-	err0602ResultNotEdgeError           errorCode = "E0602_RESULT_NOT_EDGE_ERROR"
-	err0603ResultNotElementError        errorCode = "E0603_RESULT_NOT_ELEMENT_ERROR"
-	err0604ResultNotPathError           errorCode = "E0604_RESULT_NOT_PATH_ERROR"
-	err0605ResultNotPropertyError       errorCode = "E0605_RESULT_NOT_PROPERTY_ERROR"
-	err0606ResultNotVertexPropertyError errorCode = "E0606_RESULT_NOT_VERTEX_PROPERTY_ERROR"
-	err0607ResultNotTraverserError      errorCode = "E0607_RESULT_NOT_TRAVERSER_ERROR"
-	err0608ResultNotSliceError          errorCode = "E0608_RESULT_NOT_SLICE_ERROR"
+	E0601ResultNotVertexError         ErrorCode = "E0601_RESULT_NOT_VERTEX_ERROR"
+	E0602ResultNotEdgeError           ErrorCode = "E0602_RESULT_NOT_EDGE_ERROR"
+	E0603ResultNotElementError        ErrorCode = "E0603_RESULT_NOT_ELEMENT_ERROR"
+	E0604ResultNotPathError           ErrorCode = "E0604_RESULT_NOT_PATH_ERROR"
+	E0605ResultNotPropertyError       ErrorCode = "E0605_RESULT_NOT_PROPERTY_ERROR"
+	E0606ResultNotVertexPropertyError ErrorCode = "E0606_RESULT_NOT_VERTEX_PROPERTY_ERROR"
+	E0607ResultNotTraverserError      ErrorCode = "E0607_RESULT_NOT_TRAVERSER_ERROR"
+	E0608ResultNotSliceError          ErrorCode = "E0608_RESULT_NOT_SLICE_ERROR"
+	E0609ResultNotBulkSetError        ErrorCode = "E0609_RESULT_NOT_BULK_SET_ERROR"
+
+	// resultDecoder.go errors
+	E0610ResultDecodeInvalidTargetError    ErrorCode = "E0610_RESULT_DECODE_INVALID_TARGET_ERROR"
+	E0611ResultDecodeAllInvalidTargetError ErrorCode = "E0611_RESULT_DECODE_ALL_INVALID_TARGET_ERROR"
+	E0612ResultDecodeTypeMismatchError     ErrorCode = "E0612_RESULT_DECODE_TYPE_MISMATCH_ERROR"
+	E0613ResultDecodeOverflowError         ErrorCode = "E0613_RESULT_DECODE_OVERFLOW_ERROR"
+
+	// typedResult.go errors
+	E0614ResultAsTypeError ErrorCode = "E0614_RESULT_AS_TYPE_ERROR"
 
 	// serializer.go errors
-	err0701ReadMapNullKeyError          errorCode = "E0701_SERIALIZER_READMAP_NULL_KEY_ERROR"
-	err0703ReadMapNonStringKeyError     errorCode = "E0703_SERIALIZER_READMAP_NON_STRING_KEY_ERROR"
-	err0704ConvertArgsNoSerializerError errorCode = "E0704_SERIALIZER_CONVERTARGS_NO_SERIALIZER_ERROR"
+	E0701ReadMapNullKeyError          ErrorCode = "E0701_SERIALIZER_READMAP_NULL_KEY_ERROR"
+	E0703ReadMapNonStringKeyError     ErrorCode = "E0703_SERIALIZER_READMAP_NON_STRING_KEY_ERROR"
+	E0704ConvertArgsNoSerializerError ErrorCode = "E0704_SERIALIZER_CONVERTARGS_NO_SERIALIZER_ERROR"
+	E0705UnknownSerializerTypeError   ErrorCode = "E0705_SERIALIZER_UNKNOWN_SERIALIZER_TYPE_ERROR"
 
 	// transporterFactory.go errors
-	err0801GetTransportLayerNoTypeError errorCode = "E0801_TRANSPORTERFACTORY_GETTRANSPORTLAYER_NO_TYPE_ERROR"
+	E0801GetTransportLayerNoTypeError ErrorCode = "E0801_TRANSPORTERFACTORY_GETTRANSPORTLAYER_NO_TYPE_ERROR"
+
+	// httpTransporter.go errors
+	E0802HttpTransporterClosedError             ErrorCode = "E0802_HTTPTRANSPORTER_CLOSED_ERROR"
+	E0803HttpTransporterUnsupportedRequestError ErrorCode = "E0803_HTTPTRANSPORTER_UNSUPPORTED_REQUEST_ERROR"
+	E0804HttpTransporterBindingValueError       ErrorCode = "E0804_HTTPTRANSPORTER_BINDING_VALUE_ERROR"
 
 	// traversal.go errors
-	err0901ToListAnonTraversalError  errorCode = "E0901_TRAVERSAL_TOLIST_ANON_TRAVERSAL_ERROR"
-	err0902IterateAnonTraversalError errorCode = "E0902_TRAVERSAL_ITERATE_ANON_TRAVERSAL_ERROR"
-	err0903NextNoResultsLeftError    errorCode = "E0903_TRAVERSAL_NEXT_NO_RESULTS_LEFT_ERROR"
+	E0901ToListAnonTraversalError  ErrorCode = "E0901_TRAVERSAL_TOLIST_ANON_TRAVERSAL_ERROR"
+	E0902IterateAnonTraversalError ErrorCode = "E0902_TRAVERSAL_ITERATE_ANON_TRAVERSAL_ERROR"
+	E0903NextNoResultsLeftError    ErrorCode = "E0903_TRAVERSAL_NEXT_NO_RESULTS_LEFT_ERROR"
 
 	// Bytecode.go errors
-	err1001ConvertArgumentChildTraversalNotFromAnonError errorCode = "E1001_BYTECODE_CHILD_T_NOT_ANON_ERROR"
+	E1001ConvertArgumentChildTraversalNotFromAnonError ErrorCode = "E1001_BYTECODE_CHILD_T_NOT_ANON_ERROR"
+	E1002ConvertArgumentNotStructError                 ErrorCode = "E1002_BYTECODE_PROPS_NOT_STRUCT_ERROR"
 
 	// graphTraversal.go errors
-	err1101TransactionRepeatedOpenError      errorCode = "E1101_TRANSACTION_REPEATED_OPEN_ERROR"
-	err1102TransactionRollbackNotOpenedError errorCode = "E1102_TRANSACTION_ROLLBACK_NOT_OPENED_ERROR"
-	err1103TransactionCommitNotOpenedError   errorCode = "E1103_TRANSACTION_COMMIT_NOT_OPENED_ERROR"
-	err1104TransactionRepeatedCloseError     errorCode = "E1104_TRANSACTION_REPEATED_CLOSE_ERROR"
+	E1101TransactionRepeatedOpenError      ErrorCode = "E1101_TRANSACTION_REPEATED_OPEN_ERROR"
+	E1102TransactionRollbackNotOpenedError ErrorCode = "E1102_TRANSACTION_ROLLBACK_NOT_OPENED_ERROR"
+	E1103TransactionCommitNotOpenedError   ErrorCode = "E1103_TRANSACTION_COMMIT_NOT_OPENED_ERROR"
+	E1104TransactionRepeatedCloseError     ErrorCode = "E1104_TRANSACTION_REPEATED_CLOSE_ERROR"
+
+	// graphSON.go errors
+	E1201GraphSONWriteUnknownTypeError ErrorCode = "E1201_GRAPHSON_WRITE_UNKNOWN_TYPE_ERROR"
+	E1202GraphSONReadUnknownTypeError  ErrorCode = "E1202_GRAPHSON_READ_UNKNOWN_TYPE_ERROR"
+	E1203GraphSONReadInvalidValueError ErrorCode = "E1203_GRAPHSON_READ_INVALID_VALUE_ERROR"
+
+	// translator.go errors
+	E1301TranslatorUnsupportedValueError  ErrorCode = "E1301_TRANSLATOR_UNSUPPORTED_VALUE_ERROR"
+	E1302TranslatorUnsupportedLambdaError ErrorCode = "E1302_TRANSLATOR_UNSUPPORTED_LAMBDA_ERROR"
+)
+
+// Sentinel errors for failures that applications commonly handle. Errors returned by the driver match the sentinel
+// with the same Code when compared with errors.Is.
+var (
+	ErrConnectionClosed               = &GremlinError{Code: E0102WriteConnectionClosedError}
+	ErrConnectionPoolClosed           = &GremlinError{Code: E0103ConnectionPoolClosedError}
+	ErrConnectionPoolInstantiateFail  = &GremlinError{Code: E0104ConnectionPoolInstantiateFail}
+	ErrConnectionPoolFullButNoneValid = &GremlinError{Code: E0105ConnectionPoolFullButNoneValid}
+	ErrNoHostAvailable                = &GremlinError{Code: E0106ConnectionPoolNoHostAvailableError}
+	ErrSubmitToClosedConnection       = &GremlinError{Code: E0203SubmitBytecodeToClosedConnectionError}
+	ErrAuthentication                 = &GremlinError{Code: E0503ResponseHandlerAuthError}
+	ErrNoResultsLeft                  = &GremlinError{Code: E0903NextNoResultsLeftError}
+	ErrTransactionRepeatedOpen        = &GremlinError{Code: E1101TransactionRepeatedOpenError}
+	ErrTransactionRollbackNotOpened   = &GremlinError{Code: E1102TransactionRollbackNotOpenedError}
+	ErrTransactionCommitNotOpened     = &GremlinError{Code: E1103TransactionCommitNotOpenedError}
+	ErrTransactionRepeatedClose       = &GremlinError{Code: E1104TransactionRepeatedCloseError}
 )
 
 var localizer *i18n.Localizer
@@ -116,14 +199,27 @@ func initializeLocalizer(locale language.Tag) {
 	localizer = i18n.NewLocalizer(bundle, locale.String())
 }
 
-func newError(errorCode errorCode, args ...interface{}) error {
+// newError creates a GremlinError with the localized message of the code, formatted with args. The first argument
+// which is an error becomes the cause of the GremlinError.
+func newError(errorCode ErrorCode, args ...interface{}) error {
 	config := i18n.LocalizeConfig{
 		MessageID: string(errorCode),
 	}
 	localizedMessage, _ := localizer.Localize(&config)
-	return fmt.Errorf(localizedMessage, args...)
+	gremlinError := &GremlinError{Code: errorCode, message: fmt.Sprintf(localizedMessage, args...)}
+	for _, arg := range args {
+		if cause, ok := arg.(error); ok {
+			gremlinError.cause = cause
+			break
+		}
+	}
+	return gremlinError
 }
 
 func isSameErrorCode(expectedError error, actualError error) bool {
+	var expected, actual *GremlinError
+	if errors.As(expectedError, &expected) && errors.As(actualError, &actual) {
+		return expected.Code == actual.Code
+	}
 	return strings.HasPrefix(actualError.Error(), strings.Split(expectedError.Error(), ":")[0])
 }
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGremlinError(t *testing.T) {
+	t.Run("Test newError message", func(t *testing.T) {
+		err := newError(E0104ConnectionPoolInstantiateFail, "connection refused")
+		assert.Equal(t, "E0104: no successful connections could be made: connection refused", err.Error())
+	})
+
+	t.Run("Test errors.Is with sentinel errors", func(t *testing.T) {
+		err := fmt.Errorf("submit failed: %w", newError(E0103ConnectionPoolClosedError))
+		assert.True(t, errors.Is(err, ErrConnectionPoolClosed))
+		assert.False(t, errors.Is(err, ErrNoResultsLeft))
+		assert.True(t, errors.Is(newError(E0903NextNoResultsLeftError), ErrNoResultsLeft))
+	})
+
+	t.Run("Test errors.As", func(t *testing.T) {
+		var gremlinError *GremlinError
+		assert.True(t, errors.As(newError(E0105ConnectionPoolFullButNoneValid), &gremlinError))
+		assert.Equal(t, ErrorCode("E0105_CONNECTIONPOOL_FULL_NONE_VALID"), gremlinError.Code)
+		assert.Equal(t, E0105ConnectionPoolFullButNoneValid, gremlinError.Code)
+	})
+
+	t.Run("Test cause is wrapped", func(t *testing.T) {
+		err := newError(E0104ConnectionPoolInstantiateFail, io.ErrUnexpectedEOF)
+		assert.Equal(t, "E0104: no successful connections could be made: unexpected EOF", err.Error())
+		assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+		assert.True(t, errors.Is(err, ErrConnectionPoolInstantiateFail))
+		assert.Equal(t, io.ErrUnexpectedEOF, errors.Unwrap(err))
+		assert.Nil(t, errors.Unwrap(newError(E0103ConnectionPoolClosedError)))
+	})
+
+	t.Run("Test sentinel error message", func(t *testing.T) {
+		assert.Equal(t, "E0103_CONNECTIONPOOL_CLOSED_ERROR", ErrConnectionPoolClosed.Error())
+	})
+
+	t.Run("Test isSameErrorCode", func(t *testing.T) {
+		assert.True(t, isSameErrorCode(newError(E0103ConnectionPoolClosedError), newError(E0103ConnectionPoolClosedError)))
+		assert.False(t, isSameErrorCode(newError(E0103ConnectionPoolClosedError), newError(E0105ConnectionPoolFullButNoneValid)))
+		assert.True(t, isSameErrorCode(newError(E0502ResponseHandlerReadLoopError), &ResponseError{StatusCode: 500}))
+	})
+}
@@ -20,6 +20,7 @@ under the License.
 package gremlingo
 
 import (
+	"io"
 	"net/http"
 	"net/url"
 	"sync"
@@ -129,6 +130,23 @@ func (transporter *gorillaTransporter) Read() ([]byte, error) {
 
 }
 
+// NextReader used to read the next message from the transporter as a stream. Opens connection if closed.
+func (transporter *gorillaTransporter) NextReader() (io.Reader, error) {
+	if transporter.connection == nil {
+		err := transporter.Connect()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := transporter.connection.SetReadDeadline(time.Now().Add(transporter.connSettings.keepAliveInterval * 2))
+	if err != nil {
+		return nil, err
+	}
+	_, reader, err := transporter.connection.NextReader()
+	return reader, err
+}
+
 // Close used to close a connection if it is opened.
 func (transporter *gorillaTransporter) Close() (err error) {
 	if !transporter.isClosed {
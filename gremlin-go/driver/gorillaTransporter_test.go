@@ -21,6 +21,8 @@ package gremlingo
 
 import (
 	"errors"
+	"io"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -47,6 +49,12 @@ func (conn *mockWebsocketConn) ReadMessage() (int, []byte, error) {
 	return args.Get(0).(int), args.Get(1).([]byte), args.Error(2)
 }
 
+func (conn *mockWebsocketConn) NextReader() (int, io.Reader, error) {
+	args := conn.Called()
+	reader, _ := args.Get(1).(io.Reader)
+	return args.Get(0).(int), reader, args.Error(2)
+}
+
 func (conn *mockWebsocketConn) Close() error {
 	args := conn.Called()
 	return args.Error(0)
@@ -98,6 +106,15 @@ func TestGorillaTransporter(t *testing.T) {
 			assert.Equal(t, mockMessage, string(message[:]))
 		})
 
+		t.Run("NextReader", func(t *testing.T) {
+			mockConn.On("NextReader").Return(2, strings.NewReader(mockMessage), nil)
+			reader, err := transporter.NextReader()
+			assert.Nil(t, err)
+			message, err := io.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, mockMessage, string(message))
+		})
+
 		t.Run("Close and IsClosed", func(t *testing.T) {
 			mockConn.On("Close").Return(nil)
 			isClosed := transporter.IsClosed()
@@ -122,6 +139,14 @@ func TestGorillaTransporter(t *testing.T) {
 			assert.Equal(t, mockReadErrMessage, err.Error())
 		})
 
+		t.Run("NextReader", func(t *testing.T) {
+			mockConn.On("NextReader").Return(0, nil, errors.New(mockReadErrMessage))
+			reader, err := transporter.NextReader()
+			assert.Nil(t, reader)
+			assert.NotNil(t, err)
+			assert.Equal(t, mockReadErrMessage, err.Error())
+		})
+
 		t.Run("Close and IsClosed", func(t *testing.T) {
 			mockConn.On("Close").Return(nil)
 			isClosed := transporter.IsClosed()
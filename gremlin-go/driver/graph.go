@@ -25,8 +25,181 @@ import (
 	"strings"
 )
 
-// Graph is used to store the graph.
+// Graph is an in-memory graph of vertices and edges, such as the result of the subgraph step. Vertices and edges are
+// kept in the order they were added and can be looked up by their Id and label. The zero value is an empty Graph.
 type Graph struct {
+	vertices    []*Vertex
+	edges       []*Edge
+	vertexIndex map[interface{}]*Vertex
+	edgeIndex   map[interface{}]*Edge
+	outEdges    map[interface{}][]*Edge
+	inEdges     map[interface{}][]*Edge
+}
+
+// NewGraph creates an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// graphKey returns the key of an element Id in the indices of a Graph. Ids which cannot be map keys are keyed by
+// their type and string representation.
+func graphKey(id interface{}) interface{} {
+	if id == nil || reflect.TypeOf(id).Comparable() {
+		return id
+	}
+	return fmt.Sprintf("%T:%v", id, id)
+}
+
+// AddVertex adds a Vertex to the Graph, replacing any Vertex with the same Id.
+func (g *Graph) AddVertex(v *Vertex) {
+	if g.vertexIndex == nil {
+		g.vertexIndex = make(map[interface{}]*Vertex)
+	}
+	key := graphKey(v.Id)
+	if existing, ok := g.vertexIndex[key]; ok {
+		for i, vertex := range g.vertices {
+			if vertex == existing {
+				g.vertices[i] = v
+				break
+			}
+		}
+	} else {
+		g.vertices = append(g.vertices, v)
+	}
+	g.vertexIndex[key] = v
+}
+
+// AddEdge adds an Edge to the Graph, replacing any Edge with the same Id. The vertices of the Edge do not need to be
+// part of the Graph.
+func (g *Graph) AddEdge(e *Edge) {
+	if g.edgeIndex == nil {
+		g.edgeIndex = make(map[interface{}]*Edge)
+		g.outEdges = make(map[interface{}][]*Edge)
+		g.inEdges = make(map[interface{}][]*Edge)
+	}
+	key := graphKey(e.Id)
+	if existing, ok := g.edgeIndex[key]; ok {
+		for i, edge := range g.edges {
+			if edge == existing {
+				g.edges[i] = e
+				break
+			}
+		}
+		g.outEdges[graphKey(existing.OutV.Id)] = removeEdge(g.outEdges[graphKey(existing.OutV.Id)], existing)
+		g.inEdges[graphKey(existing.InV.Id)] = removeEdge(g.inEdges[graphKey(existing.InV.Id)], existing)
+	} else {
+		g.edges = append(g.edges, e)
+	}
+	g.edgeIndex[key] = e
+	g.outEdges[graphKey(e.OutV.Id)] = append(g.outEdges[graphKey(e.OutV.Id)], e)
+	g.inEdges[graphKey(e.InV.Id)] = append(g.inEdges[graphKey(e.InV.Id)], e)
+}
+
+func removeEdge(edges []*Edge, e *Edge) []*Edge {
+	for i, edge := range edges {
+		if edge == e {
+			return append(edges[:i:i], edges[i+1:]...)
+		}
+	}
+	return edges
+}
+
+// Vertices returns the vertices of the Graph.
+func (g *Graph) Vertices() []*Vertex {
+	return g.vertices
+}
+
+// Edges returns the edges of the Graph.
+func (g *Graph) Edges() []*Edge {
+	return g.edges
+}
+
+// Vertex returns the Vertex with the given Id, or nil if the Graph has no such Vertex.
+func (g *Graph) Vertex(id interface{}) *Vertex {
+	return g.vertexIndex[graphKey(id)]
+}
+
+// Edge returns the Edge with the given Id, or nil if the Graph has no such Edge.
+func (g *Graph) Edge(id interface{}) *Edge {
+	return g.edgeIndex[graphKey(id)]
+}
+
+// VerticesByLabel returns the vertices of the Graph with the given label.
+func (g *Graph) VerticesByLabel(label string) []*Vertex {
+	var vertices []*Vertex
+	for _, v := range g.vertices {
+		if v.Label == label {
+			vertices = append(vertices, v)
+		}
+	}
+	return vertices
+}
+
+// EdgesByLabel returns the edges of the Graph with the given label.
+func (g *Graph) EdgesByLabel(label string) []*Edge {
+	var edges []*Edge
+	for _, e := range g.edges {
+		if e.Label == label {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// VertexEdges returns the edges of the Graph which are incident to the Vertex with the given Id in the given
+// Direction. If labels are given, only edges with one of the labels are returned.
+func (g *Graph) VertexEdges(id interface{}, dir direction, labels ...string) []*Edge {
+	var edges []*Edge
+	key := graphKey(id)
+	if dir == Direction.Out || dir == Direction.Both {
+		edges = appendEdgesWithLabels(edges, g.outEdges[key], labels)
+	}
+	if dir == Direction.In || dir == Direction.Both {
+		for _, e := range g.inEdges[key] {
+			// Self-loops were already added as out edges.
+			if dir == Direction.Both && graphKey(e.OutV.Id) == key {
+				continue
+			}
+			edges = appendEdgesWithLabels(edges, []*Edge{e}, labels)
+		}
+	}
+	return edges
+}
+
+func appendEdgesWithLabels(edges []*Edge, candidates []*Edge, labels []string) []*Edge {
+	for _, e := range candidates {
+		if len(labels) == 0 {
+			edges = append(edges, e)
+			continue
+		}
+		for _, label := range labels {
+			if e.Label == label {
+				edges = append(edges, e)
+				break
+			}
+		}
+	}
+	return edges
+}
+
+// AdjacentVertices returns the vertices which are adjacent to the Vertex with the given Id in the given Direction,
+// once for each edge which connects them. If labels are given, only edges with one of the labels are followed.
+// Vertices which are not part of the Graph are returned as they are referenced by the edge.
+func (g *Graph) AdjacentVertices(id interface{}, dir direction, labels ...string) []*Vertex {
+	var vertices []*Vertex
+	key := graphKey(id)
+	for _, e := range g.VertexEdges(id, dir, labels...) {
+		other := e.InV
+		if graphKey(e.InV.Id) == key && (dir == Direction.In || graphKey(e.OutV.Id) != key) {
+			other = e.OutV
+		}
+		if v := g.Vertex(other.Id); v != nil {
+			vertices = append(vertices, v)
+		} else {
+			vertices = append(vertices, &Vertex{Element{Id: other.Id, Label: other.Label}})
+		}
+	}
+	return vertices
 }
 
 // Element is the base structure for both Vertex and Edge.
@@ -101,7 +274,7 @@ func (p *Path) String() string {
 // GetPathObject returns the Value that corresponds to the Key for the Path and error if the Value is not present or cannot be retrieved.
 func (p *Path) GetPathObject(key string) (interface{}, error) {
 	if len(p.Objects) != len(p.Labels) {
-		return nil, newError(err0301GetPathObjectInvalidPathUnequalLengthsError)
+		return nil, newError(E0301GetPathObjectInvalidPathUnequalLengthsError)
 	}
 	var objectList []interface{}
 	var object interface{}
@@ -109,7 +282,7 @@ func (p *Path) GetPathObject(key string) (interface{}, error) {
 		for _, label := range labelSet.ToSlice() {
 			// Sets in labels can only contain string types
 			if reflect.TypeOf(label).Kind() != reflect.String {
-				return nil, newError(err0302GetPathObjectInvalidPathNonStringLabelError)
+				return nil, newError(E0302GetPathObjectInvalidPathNonStringLabelError)
 			}
 			if label == key {
 				if object == nil {
@@ -127,7 +300,7 @@ func (p *Path) GetPathObject(key string) (interface{}, error) {
 	} else if object != nil {
 		return object, nil
 	} else {
-		return nil, newError(err0303GetPathNoLabelFoundError, key)
+		return nil, newError(E0303GetPathNoLabelFoundError, key)
 	}
 }
 
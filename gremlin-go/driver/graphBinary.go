@@ -20,12 +20,17 @@ under the License.
 package gremlingo
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
+	"net"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,6 +43,7 @@ type dataType uint8
 
 // dataType defined as constants.
 const (
+	customType            dataType = 0x00
 	intType               dataType = 0x01
 	longType              dataType = 0x02
 	stringType            dataType = 0x03
@@ -53,6 +59,7 @@ const (
 	edgeType              dataType = 0x0d
 	pathType              dataType = 0x0e
 	propertyType          dataType = 0x0f
+	graphType             dataType = 0x10
 	vertexType            dataType = 0x11
 	vertexPropertyType    dataType = 0x12
 	barrierType           dataType = 0x13
@@ -79,10 +86,25 @@ const (
 	textPType             dataType = 0x28
 	traversalStrategyType dataType = 0x29
 	bulkSetType           dataType = 0x2a
+	treeType              dataType = 0x2b
 	mergeType             dataType = 0x2e
 	metricsType           dataType = 0x2c
 	traversalMetricsType  dataType = 0x2d
+	charType              dataType = 0x80
 	durationType          dataType = 0x81
+	inetAddressType       dataType = 0x82
+	instantType           dataType = 0x83
+	localDateType         dataType = 0x84
+	localDateTimeType     dataType = 0x85
+	localTimeType         dataType = 0x86
+	monthDayType          dataType = 0x87
+	offsetDateTimeType    dataType = 0x88
+	offsetTimeType        dataType = 0x89
+	periodType            dataType = 0x8a
+	yearType              dataType = 0x8b
+	yearMonthType         dataType = 0x8c
+	zonedDateTimeType     dataType = 0x8d
+	zoneOffsetType        dataType = 0x8e
 	nullType              dataType = 0xFE
 )
 
@@ -109,7 +131,7 @@ func (serializer *graphBinaryTypeSerializer) writeTypeValue(value interface{}, b
 	if value == nil {
 		if !nullable {
 			serializer.logHandler.log(Error, unexpectedNull)
-			return nil, newError(err0401WriteTypeValueUnexpectedNullError)
+			return nil, newError(E0401WriteTypeValueUnexpectedNullError)
 		}
 		serializer.writeValueFlagNull(buffer)
 		return buffer.Bytes(), nil
@@ -239,7 +261,7 @@ func bytecodeWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *gra
 	case *Bytecode:
 		bc = *typedVal
 	default:
-		return nil, newError(err0402BytecodeWriterError)
+		return nil, newError(E0402BytecodeWriterError)
 	}
 
 	// Write {steps_length} and {step_0} through {step_n}, then {sources_length} and {source_0} through {source_n}
@@ -490,6 +512,163 @@ func pathWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBi
 	return buffer.Bytes(), nil
 }
 
+// Format: {vertex_length}{vertex_0}...{vertex_n}{edge_length}{edge_0}...{edge_n}, where each vertex is
+// {Id}{Label}{property_length}{property_0}...{property_n} with each property as {Id}{Label}{Value}{parent}{properties}
+// and each edge is {Id}{Label}{inVId}{inVLabel}{outVId}{outVLabel}{parent}{properties}. The labels and the properties
+// are written without type info or value flag.
+func graphWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
+	g := value.(*Graph)
+	err := binary.Write(buffer, binary.BigEndian, int32(len(g.Vertices())))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range g.Vertices() {
+		_, err = typeSerializer.write(v.Id, buffer)
+		if err != nil {
+			return nil, err
+		}
+		// Not fully qualified.
+		_, err = typeSerializer.writeValue(v.Label, buffer, false)
+		if err != nil {
+			return nil, err
+		}
+		properties := graphElementProperties(v.Properties)
+		err = binary.Write(buffer, binary.BigEndian, int32(len(properties)))
+		if err != nil {
+			return nil, err
+		}
+		for _, property := range properties {
+			vp, ok := property.(*VertexProperty)
+			if !ok {
+				return nil, newError(E0407GetSerializerToWriteUnknownTypeError, reflect.TypeOf(property).Name())
+			}
+			_, err = typeSerializer.write(vp.Id, buffer)
+			if err != nil {
+				return nil, err
+			}
+			// Not fully qualified.
+			_, err = typeSerializer.writeValue(vp.Label, buffer, false)
+			if err != nil {
+				return nil, err
+			}
+			_, err = typeSerializer.write(vp.Value, buffer)
+			if err != nil {
+				return nil, err
+			}
+			// The parent is always null.
+			buffer.Write(nullBytes)
+			// Not fully qualified.
+			_, err = typeSerializer.writeValue(graphElementProperties(vp.Properties), buffer, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = binary.Write(buffer, binary.BigEndian, int32(len(g.Edges())))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range g.Edges() {
+		_, err = typeSerializer.write(e.Id, buffer)
+		if err != nil {
+			return nil, err
+		}
+		// Not fully qualified.
+		_, err = typeSerializer.writeValue(e.Label, buffer, false)
+		if err != nil {
+			return nil, err
+		}
+		// The labels of the vertices and the parent are always null.
+		_, err = typeSerializer.write(e.InV.Id, buffer)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(nullBytes)
+		_, err = typeSerializer.write(e.OutV.Id, buffer)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(nullBytes)
+		buffer.Write(nullBytes)
+		// Not fully qualified.
+		_, err = typeSerializer.writeValue(graphElementProperties(e.Properties), buffer, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// graphElementProperties returns the properties of an element of a Graph as they are read by graphReader.
+func graphElementProperties(properties interface{}) []interface{} {
+	if list, ok := properties.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{}
+}
+
+// Format: {length}{item_0}...{item_n}, where each item is a fully qualified key followed by the subtree of the key
+// without type info or value flag.
+func treeWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
+	var tree *Tree
+	if reflect.TypeOf(value).Kind() == reflect.Ptr {
+		tree = value.(*Tree)
+	} else {
+		v := value.(Tree)
+		tree = &v
+	}
+	err := binary.Write(buffer, binary.BigEndian, int32(len(tree.Children)))
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range tree.Children {
+		_, err = typeSerializer.write(child.Key, buffer)
+		if err != nil {
+			return nil, err
+		}
+		_, err = treeWriter(&child.Tree, buffer, typeSerializer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// Format: {length}{item_0}...{item_n}, where each item is a fully qualified value followed by its bulk as a Long.
+func bulkSetWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
+	b := value.(*BulkSet)
+	err := binary.Write(buffer, binary.BigEndian, int32(len(b.values)))
+	if err != nil {
+		return nil, err
+	}
+	for j, v := range b.values {
+		_, err = typeSerializer.write(v, buffer)
+		if err != nil {
+			return nil, err
+		}
+		err = binary.Write(buffer, binary.BigEndian, b.bulks[j])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// Format: {bulk}{value}, where bulk is a Long and value is fully qualified.
+func traverserWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
+	t := value.(*Traverser)
+	err := binary.Write(buffer, binary.BigEndian, t.bulk)
+	if err != nil {
+		return nil, err
+	}
+	_, err = typeSerializer.write(t.value, buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
 // Format: Same as List.
 // Mostly similar to listWriter with small changes
 func setWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
@@ -521,6 +700,112 @@ func durationWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeS
 	return buffer.Bytes(), nil
 }
 
+// writeBigEndian writes each of the values in big-endian order.
+func writeBigEndian(buffer *bytes.Buffer, values ...interface{}) ([]byte, error) {
+	for _, value := range values {
+		err := binary.Write(buffer, binary.BigEndian, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// Format: one to four bytes of the UTF-8 encoding of the character.
+func charWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	buffer.WriteRune(rune(value.(Char)))
+	return buffer.Bytes(), nil
+}
+
+// Format: {length}{address}, where address is the 4 bytes of an IPv4 or the 16 bytes of an IPv6 address.
+func inetAddressWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	ip := value.(net.IP)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	} else if ip = ip.To16(); ip == nil {
+		return nil, newError(E0410WriteInvalidInetAddressError, len(value.(net.IP)))
+	}
+	return writeBigEndian(buffer, int32(len(ip)), []byte(ip))
+}
+
+// Format: {seconds}{nanos}, where seconds is a Long and nanos is an Int.
+func instantWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	t := value.(Instant)
+	return writeBigEndian(buffer, t.Unix(), int32(t.Nanosecond()))
+}
+
+func localDateFields(d LocalDate) []interface{} {
+	return []interface{}{int32(d.Year), int8(d.Month), int8(d.Day)}
+}
+
+// Format: {year}{month}{day}, where year is an Int and month and day are Bytes.
+func localDateWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeBigEndian(buffer, localDateFields(value.(LocalDate))...)
+}
+
+// Format: {date}{time}, where date is a LocalDate and time is a LocalTime.
+func localDateTimeWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	dt := value.(LocalDateTime)
+	return writeBigEndian(buffer, append(localDateFields(dt.Date), dt.Time.nanoOfDay())...)
+}
+
+// Format: A Long with the nanoseconds since midnight.
+func localTimeWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeBigEndian(buffer, value.(LocalTime).nanoOfDay())
+}
+
+// Format: {month}{day}, where month and day are Bytes.
+func monthDayWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	md := value.(MonthDay)
+	return writeBigEndian(buffer, int8(md.Month), int8(md.Day))
+}
+
+// Writes the LocalDate and LocalTime of a time.Time in its location followed by its ZoneOffset.
+func writeOffsetDateTime(t time.Time, buffer *bytes.Buffer) ([]byte, error) {
+	_, offset := t.Zone()
+	date := LocalDate{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+	localTime := LocalTime{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+	return writeBigEndian(buffer, append(localDateFields(date), localTime.nanoOfDay(), int32(offset))...)
+}
+
+// Format: {local_date_time}{offset}, where local_date_time is a LocalDateTime and offset is a ZoneOffset.
+func offsetDateTimeWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeOffsetDateTime(value.(OffsetDateTime).Time, buffer)
+}
+
+// Format: {local_time}{offset}, where local_time is a LocalTime and offset is a ZoneOffset.
+func offsetTimeWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	t := value.(OffsetTime)
+	return writeBigEndian(buffer, t.Time.nanoOfDay(), int32(t.Offset))
+}
+
+// Format: {years}{months}{days}, where each is an Int.
+func periodWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	p := value.(Period)
+	return writeBigEndian(buffer, int32(p.Years), int32(p.Months), int32(p.Days))
+}
+
+// Format: An Int with the year.
+func yearWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeBigEndian(buffer, int32(value.(Year)))
+}
+
+// Format: {year}{month}, where year is an Int and month is a Byte.
+func yearMonthWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	ym := value.(YearMonth)
+	return writeBigEndian(buffer, int32(ym.Year), int8(ym.Month))
+}
+
+// Format: {local_date_time}{zone_offset}, where local_date_time is a LocalDateTime and zone_offset is a ZoneOffset.
+func zonedDateTimeWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeOffsetDateTime(value.(ZonedDateTime).Time, buffer)
+}
+
+// Format: An Int with the total offset in seconds.
+func zoneOffsetWriter(value interface{}, buffer *bytes.Buffer, _ *graphBinaryTypeSerializer) ([]byte, error) {
+	return writeBigEndian(buffer, int32(value.(ZoneOffset)))
+}
+
 const (
 	valueFlagNull byte = 1
 	valueFlagNone byte = 0
@@ -644,6 +929,9 @@ func bindingWriter(value interface{}, buffer *bytes.Buffer, typeSerializer *grap
 }
 
 func (serializer *graphBinaryTypeSerializer) getType(val interface{}) (dataType, error) {
+	if isCustomType(val) {
+		return customType, nil
+	}
 	switch val.(type) {
 	case *Bytecode, Bytecode, *GraphTraversal:
 		return bytecodeType, nil
@@ -681,12 +969,48 @@ func (serializer *graphBinaryTypeSerializer) getType(val interface{}) (dataType,
 		return traversalStrategyType, nil
 	case *Path:
 		return pathType, nil
+	case *Tree, Tree:
+		return treeType, nil
+	case *BulkSet:
+		return bulkSetType, nil
+	case *Traverser:
+		return traverserType, nil
+	case *Graph:
+		return graphType, nil
 	case Set:
 		return setType, nil
 	case time.Time:
 		return dateType, nil
 	case time.Duration:
 		return durationType, nil
+	case Char:
+		return charType, nil
+	case net.IP:
+		return inetAddressType, nil
+	case Instant:
+		return instantType, nil
+	case LocalDate:
+		return localDateType, nil
+	case LocalDateTime:
+		return localDateTimeType, nil
+	case LocalTime:
+		return localTimeType, nil
+	case MonthDay:
+		return monthDayType, nil
+	case OffsetDateTime:
+		return offsetDateTimeType, nil
+	case OffsetTime:
+		return offsetTimeType, nil
+	case Period:
+		return periodType, nil
+	case Year:
+		return yearType, nil
+	case YearMonth:
+		return yearMonthType, nil
+	case ZonedDateTime:
+		return zonedDateTimeType, nil
+	case ZoneOffset:
+		return zoneOffsetType, nil
 	case cardinality:
 		return cardinalityType, nil
 	case column:
@@ -734,7 +1058,7 @@ func (serializer *graphBinaryTypeSerializer) getType(val interface{}) (dataType,
 			return listType, nil
 		default:
 			serializer.logHandler.logf(Error, serializeDataTypeError, reflect.TypeOf(val).Name())
-			return intType, newError(err0407GetSerializerToWriteUnknownTypeError, reflect.TypeOf(val).Name())
+			return intType, newError(E0407GetSerializerToWriteUnknownTypeError, reflect.TypeOf(val).Name())
 		}
 	}
 }
@@ -744,7 +1068,7 @@ func (serializer *graphBinaryTypeSerializer) getWriter(dataType dataType) (write
 		return writer, nil
 	}
 	serializer.logHandler.logf(Error, deserializeDataTypeError, int32(dataType))
-	return nil, newError(err0407GetSerializerToWriteUnknownTypeError, dataType)
+	return nil, newError(E0407GetSerializerToWriteUnknownTypeError, dataType)
 }
 
 // gets the type of the serializer based on the value
@@ -774,6 +1098,10 @@ func (serializer *graphBinaryTypeSerializer) write(valueObject interface{}, buff
 		return nil, err
 	}
 	buffer.Write(dataType.getCodeBytes())
+	if dataType == customType {
+		// The value flag of a custom type follows its name and type info, so the writer writes it.
+		return writer(valueObject, buffer, serializer)
+	}
 	return serializer.writeType(valueObject, buffer, writer)
 }
 
@@ -782,7 +1110,7 @@ func (serializer *graphBinaryTypeSerializer) writeValue(value interface{}, buffe
 	if value == nil {
 		if !nullable {
 			serializer.logHandler.log(Error, unexpectedNull)
-			return nil, newError(err0403WriteValueUnexpectedNullError)
+			return nil, newError(E0403WriteValueUnexpectedNullError)
 		}
 		serializer.writeValueFlagNull(buffer)
 		return buffer.Bytes(), nil
@@ -809,63 +1137,216 @@ func (serializer *graphBinaryTypeSerializer) writeValueFlagNone(buffer *bytes.Bu
 
 // readers
 
-func readTemp(data *[]byte, i *int, len int) *[]byte {
-	tmp := make([]byte, len)
-	for j := 0; j < len; j++ {
-		tmp[j] = (*data)[j+*i]
+// decoderBufferSize is the size of the buffer from which values are decoded. Only primitives are read from the buffer
+// directly, so it bounds the number of bytes a message is read ahead rather than the size of the values.
+const decoderBufferSize = 4096
+
+// maxPreallocatedLength bounds the capacity that is allocated ahead for a collection, so that a corrupt length cannot
+// allocate more memory than the data which is actually read.
+const maxPreallocatedLength = 1024
+
+// graphBinaryDecoder decodes GraphBinary values from a stream. Decoders are pooled together with their buffer, so
+// that decoding a message does not need to allocate either of them.
+type graphBinaryDecoder struct {
+	reader *bufio.Reader
+	// offset is the number of bytes that were decoded so far.
+	offset int
+	// readErr is the error of the underlying reader, if it failed.
+	readErr error
+	// request is set if a request is decoded, which may hold types that only clients write.
+	request bool
+}
+
+var decoderPool = sync.Pool{
+	New: func() interface{} {
+		return &graphBinaryDecoder{reader: bufio.NewReaderSize(nil, decoderBufferSize)}
+	},
+}
+
+// newGraphBinaryDecoder returns a decoder of the GraphBinary data read from r. It should be released once the data is
+// decoded.
+func newGraphBinaryDecoder(r io.Reader) *graphBinaryDecoder {
+	d := decoderPool.Get().(*graphBinaryDecoder)
+	d.reader.Reset(r)
+	d.offset, d.readErr, d.request = 0, nil, false
+	return d
+}
+
+// deserializer returns the reader of the data type.
+func (d *graphBinaryDecoder) deserializer(dataTyp dataType) (reader, bool) {
+	deserializer, ok := deserializers[dataTyp]
+	if !ok && d.request {
+		deserializer, ok = requestDeserializers[dataTyp]
+	}
+	return deserializer, ok
+}
+
+// release returns the decoder to the pool. No value that was decoded refers to the buffer of the decoder.
+func (d *graphBinaryDecoder) release() {
+	d.reader.Reset(nil)
+	decoderPool.Put(d)
+}
+
+// next returns the next n bytes, which must not exceed the size of the buffer. The bytes are not copied, so they are
+// only valid until the next read.
+func (d *graphBinaryDecoder) next(n int) ([]byte, error) {
+	b, err := d.reader.Peek(n)
+	if err != nil {
+		return nil, d.truncated(n, len(b), err)
+	}
+	_, _ = d.reader.Discard(n)
+	d.offset += n
+	return b, nil
+}
+
+// readBytes reads the next n bytes into a new slice. Large slices grow as their bytes are read, so that a corrupt
+// length cannot allocate more memory than the data which is actually read.
+func (d *graphBinaryDecoder) readBytes(n int) ([]byte, error) {
+	if n <= decoderBufferSize {
+		b, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append(make([]byte, 0, n), b...), nil
+	}
+	var buffer bytes.Buffer
+	read, err := io.CopyN(&buffer, d.reader, int64(n))
+	d.offset += int(read)
+	if err != nil {
+		return nil, d.truncated(n, int(read), err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// skip skips the next n bytes.
+func (d *graphBinaryDecoder) skip(n int) error {
+	skipped, err := d.reader.Discard(n)
+	d.offset += skipped
+	if err != nil {
+		return d.truncated(n, skipped, err)
+	}
+	return nil
+}
+
+// truncated returns the error of a read of n bytes which stopped after read bytes. Errors other than the end of the
+// data are errors of the underlying reader and are returned as they are.
+func (d *graphBinaryDecoder) truncated(n int, read int, err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return newError(E0412ReadTruncatedDataError, n, read)
+	}
+	d.readErr = err
+	return err
+}
+
+// failure returns the error with which decoding a message fails because of err, which happened while reading a value
+// of type dataTyp. Failures of the underlying reader are returned as they are, as they are not caused by the data.
+func (d *graphBinaryDecoder) failure(err error, dataTyp dataType) error {
+	if d.readErr != nil {
+		return d.readErr
+	}
+	return decodingError(err, d.offset, dataTyp)
+}
+
+// decodingError wraps err, the failure to read a value of type dataTyp at offset, into a DecodingError. Errors of
+// nested values are already wrapped and are returned as is, so that they point at the innermost value which failed.
+func decodingError(err error, offset int, dataTyp dataType) error {
+	var decodingErr *DecodingError
+	if errors.As(err, &decodingErr) {
+		return err
+	}
+	return &DecodingError{Offset: offset, TypeCode: byte(dataTyp), Err: err}
+}
+
+// readLength reads the length of a string or collection.
+func readLength(d *graphBinaryDecoder) (int, error) {
+	length, err := readIntSafe(d)
+	if err != nil {
+		return 0, err
+	}
+	if length < 0 {
+		return 0, newError(E0413ReadInvalidLengthError, length)
+	}
+	return int(length), nil
+}
+
+// preallocatedLength returns the capacity to allocate ahead for a collection of the given length.
+func preallocatedLength(length int) int {
+	if length > maxPreallocatedLength {
+		return maxPreallocatedLength
 	}
-	*i += len
-	return &tmp
+	return length
 }
 
 // Primitive
-func readBoolean(data *[]byte, i *int) (interface{}, error) {
-	b, _ := readByte(data, i)
+func readBoolean(d *graphBinaryDecoder) (interface{}, error) {
+	b, err := readByteSafe(d)
+	if err != nil {
+		return nil, err
+	}
 	return b != uint8(0), nil
 }
 
-func readByteSafe(data *[]byte, i *int) byte {
-	*i++
-	return (*data)[*i-1]
+func readByteSafe(d *graphBinaryDecoder) (byte, error) {
+	b, err := d.reader.ReadByte()
+	if err != nil {
+		return 0, d.truncated(1, 0, err)
+	}
+	d.offset++
+	return b, nil
 }
-func readByte(data *[]byte, i *int) (interface{}, error) {
-	return readByteSafe(data, i), nil
+func readByte(d *graphBinaryDecoder) (interface{}, error) {
+	return readByteSafe(d)
 }
 
-func readShort(data *[]byte, i *int) (interface{}, error) {
-	return int16(binary.BigEndian.Uint16(*readTemp(data, i, 2))), nil
+func readShort(d *graphBinaryDecoder) (interface{}, error) {
+	b, err := d.next(2)
+	if err != nil {
+		return nil, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
 }
 
-func readIntSafe(data *[]byte, i *int) int32 {
-	return int32(binary.BigEndian.Uint32(*readTemp(data, i, 4)))
+func readIntSafe(d *graphBinaryDecoder) (int32, error) {
+	u, err := readUint32Safe(d)
+	return int32(u), err
 }
-func readInt(data *[]byte, i *int) (interface{}, error) {
-	return readIntSafe(data, i), nil
+func readInt(d *graphBinaryDecoder) (interface{}, error) {
+	return readIntSafe(d)
 }
 
-func readLongSafe(data *[]byte, i *int) int64 {
-	return int64(binary.BigEndian.Uint64(*readTemp(data, i, 8)))
+func readLongSafe(d *graphBinaryDecoder) (int64, error) {
+	b, err := d.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
 }
-func readLong(data *[]byte, i *int) (interface{}, error) {
-	return readLongSafe(data, i), nil
+func readLong(d *graphBinaryDecoder) (interface{}, error) {
+	return readLongSafe(d)
 }
 
-func readBigInt(data *[]byte, i *int) (interface{}, error) {
-	sz := readIntSafe(data, i)
-	b := readTemp(data, i, int(sz))
+func readBigInt(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	b, err := d.readBytes(sz)
+	if err != nil {
+		return nil, err
+	}
 
-	var newBigInt = big.NewInt(0).SetBytes(*b)
+	var newBigInt = big.NewInt(0).SetBytes(b)
 	var one = big.NewInt(1)
-	if len(*b) == 0 {
+	if len(b) == 0 {
 		return newBigInt, nil
 	}
 	// If the first bit in the first element of the byte array is a 1, we need to interpret the byte array as a two's complement representation
-	if (*b)[0]&0x80 == 0x00 {
-		newBigInt.SetBytes(*b)
+	if b[0]&0x80 == 0x00 {
+		newBigInt.SetBytes(b)
 		return newBigInt, nil
 	}
 	// Undo two's complement to byte array and set negative boolean to true
-	length := uint((len(*b)*8)/8+1) * 8
+	length := uint((len(b)*8)/8+1) * 8
 	b2 := new(big.Int).Sub(newBigInt, new(big.Int).Lsh(one, length)).Bytes()
 
 	// Strip the resulting 0xff byte at the start of array
@@ -881,10 +1362,14 @@ func readBigInt(data *[]byte, i *int) (interface{}, error) {
 	return newBigInt, nil
 }
 
-func readBigDecimal(data *[]byte, i *int) (interface{}, error) {
+func readBigDecimal(d *graphBinaryDecoder) (interface{}, error) {
+	var err error
 	bigDecimal := &BigDecimal{}
-	bigDecimal.Scale = readIntSafe(data, i)
-	unscaled, err := readBigInt(data, i)
+	bigDecimal.Scale, err = readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	unscaled, err := readBigInt(d)
 	if err != nil {
 		return nil, err
 	}
@@ -892,29 +1377,56 @@ func readBigDecimal(data *[]byte, i *int) (interface{}, error) {
 	return bigDecimal, nil
 }
 
-func readUint32Safe(data *[]byte, i *int) uint32 {
-	return binary.BigEndian.Uint32(*readTemp(data, i, 4))
+func readUint32Safe(d *graphBinaryDecoder) (uint32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
 }
 
-func readFloat(data *[]byte, i *int) (interface{}, error) {
-	return math.Float32frombits(binary.BigEndian.Uint32(*readTemp(data, i, 4))), nil
+func readFloat(d *graphBinaryDecoder) (interface{}, error) {
+	u, err := readUint32Safe(d)
+	if err != nil {
+		return nil, err
+	}
+	return math.Float32frombits(u), nil
 }
 
-func readDouble(data *[]byte, i *int) (interface{}, error) {
-	return math.Float64frombits(binary.BigEndian.Uint64(*readTemp(data, i, 8))), nil
+func readDouble(d *graphBinaryDecoder) (interface{}, error) {
+	l, err := readLongSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return math.Float64frombits(uint64(l)), nil
 }
 
-func readString(data *[]byte, i *int) (interface{}, error) {
-	sz := int(readUint32Safe(data, i))
+func readString(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
 	if sz == 0 {
 		return "", nil
 	}
-	*i += sz
-	return string((*data)[*i-sz : *i]), nil
+	if sz <= decoderBufferSize {
+		// The string is copied straight from the buffer.
+		b, err := d.next(sz)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	b, err := d.readBytes(sz)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
 }
 
-func readDataType(data *[]byte, i *int) dataType {
-	return dataType(readByteSafe(data, i))
+func readDataType(d *graphBinaryDecoder) (dataType, error) {
+	b, err := readByteSafe(d)
+	return dataType(b), err
 }
 
 func getDefaultValue(dataType dataType) interface{} {
@@ -937,7 +1449,7 @@ func getDefaultValue(dataType dataType) interface{} {
 		return Path{}
 	case setType:
 		return SimpleSet{}
-	case dateType, timestampType:
+	case dateType, timestampType, instantType, offsetDateTimeType, zonedDateTimeType:
 		return time.Time{}
 	case durationType:
 		return time.Duration(0)
@@ -947,11 +1459,17 @@ func getDefaultValue(dataType dataType) interface{} {
 }
 
 // Composite
-func readList(data *[]byte, i *int) (interface{}, error) {
-	sz := readIntSafe(data, i)
+func readList(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
 	var valList []interface{}
-	for j := int32(0); j < sz; j++ {
-		val, err := readFullyQualifiedNullable(data, i, true)
+	if sz > 0 {
+		valList = make([]interface{}, 0, preallocatedLength(sz))
+	}
+	for j := 0; j < sz; j++ {
+		val, err := readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
@@ -960,25 +1478,31 @@ func readList(data *[]byte, i *int) (interface{}, error) {
 	return valList, nil
 }
 
-func readByteBuffer(data *[]byte, i *int) (interface{}, error) {
+func readByteBuffer(d *graphBinaryDecoder) (interface{}, error) {
 	r := &ByteBuffer{}
-	sz := readIntSafe(data, i)
-	r.Data = make([]byte, sz)
-	for j := int32(0); j < sz; j++ {
-		r.Data[j] = readByteSafe(data, i)
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	r.Data, err = d.readBytes(sz)
+	if err != nil {
+		return nil, err
 	}
 	return r, nil
 }
 
-func readMap(data *[]byte, i *int) (interface{}, error) {
-	sz := readUint32Safe(data, i)
-	var mapData = make(map[interface{}]interface{})
-	for j := uint32(0); j < sz; j++ {
-		k, err := readFullyQualifiedNullable(data, i, true)
+func readMap(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	var mapData = make(map[interface{}]interface{}, preallocatedLength(sz))
+	for j := 0; j < sz; j++ {
+		k, err := readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
-		v, err := readFullyQualifiedNullable(data, i, true)
+		v, err := readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
@@ -1001,23 +1525,31 @@ func readMap(data *[]byte, i *int) (interface{}, error) {
 	return mapData, nil
 }
 
-func readMapUnqualified(data *[]byte, i *int) (interface{}, error) {
-	sz := readUint32Safe(data, i)
-	var mapData = make(map[string]interface{})
-	for j := uint32(0); j < sz; j++ {
-		keyDataType := readDataType(data, i)
+func readMapUnqualified(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	var mapData = make(map[string]interface{}, preallocatedLength(sz))
+	for j := 0; j < sz; j++ {
+		keyDataType, err := readDataType(d)
+		if err != nil {
+			return nil, err
+		}
 		if keyDataType != stringType {
-			return nil, newError(err0703ReadMapNonStringKeyError)
+			return nil, newError(E0703ReadMapNonStringKeyError)
 		}
 
 		// Skip nullable, key must be present
-		*i++
+		if err = d.skip(1); err != nil {
+			return nil, err
+		}
 
-		k, err := readString(data, i)
+		k, err := readString(d)
 		if err != nil {
 			return nil, err
 		}
-		mapData[k.(string)], err = readFullyQualifiedNullable(data, i, true)
+		mapData[k.(string)], err = readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
@@ -1025,49 +1557,218 @@ func readMapUnqualified(data *[]byte, i *int) (interface{}, error) {
 	return mapData, nil
 }
 
-func readSet(data *[]byte, i *int) (interface{}, error) {
-	list, err := readList(data, i)
+func readSet(d *graphBinaryDecoder) (interface{}, error) {
+	list, err := readList(d)
 	if err != nil {
 		return nil, err
 	}
 	return NewSimpleSet(list.([]interface{})...), nil
 }
 
-func readUuid(data *[]byte, i *int) (interface{}, error) {
-	id, _ := uuid.FromBytes(*readTemp(data, i, 16))
+func readUuid(d *graphBinaryDecoder) (interface{}, error) {
+	b, err := d.next(16)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := uuid.FromBytes(b)
 	return id, nil
 }
 
-func timeReader(data *[]byte, i *int) (interface{}, error) {
-	return time.UnixMilli(readLongSafe(data, i)), nil
+func timeReader(d *graphBinaryDecoder) (interface{}, error) {
+	millis, err := readLongSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return time.UnixMilli(millis), nil
 }
 
-func durationReader(data *[]byte, i *int) (interface{}, error) {
-	return time.Duration(readLongSafe(data, i)*int64(time.Second) + int64(readIntSafe(data, i))), nil
+// readSecondsAndNanos reads the seconds and nanoseconds of durations and instants.
+func readSecondsAndNanos(d *graphBinaryDecoder) (int64, int32, error) {
+	seconds, err := readLongSafe(d)
+	if err != nil {
+		return 0, 0, err
+	}
+	nanos, err := readIntSafe(d)
+	return seconds, nanos, err
 }
 
-// Graph
+func durationReader(d *graphBinaryDecoder) (interface{}, error) {
+	seconds, nanos, err := readSecondsAndNanos(d)
+	if err != nil {
+		return nil, err
+	}
+	return time.Duration(seconds*int64(time.Second) + int64(nanos)), nil
+}
+
+// Chars are read as rune.
+func readChar(d *graphBinaryDecoder) (interface{}, error) {
+	r, size, err := d.reader.ReadRune()
+	if err != nil {
+		return nil, d.truncated(1, 0, err)
+	}
+	d.offset += size
+	return r, nil
+}
+
+func readInetAddress(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	b, err := d.readBytes(sz)
+	if err != nil {
+		return nil, err
+	}
+	return net.IP(b), nil
+}
+
+func readInstant(d *graphBinaryDecoder) (interface{}, error) {
+	seconds, nanos, err := readSecondsAndNanos(d)
+	if err != nil {
+		return nil, err
+	}
+	return time.Unix(seconds, int64(nanos)).UTC(), nil
+}
+
+func readLocalDateSafe(d *graphBinaryDecoder) (LocalDate, error) {
+	year, err := readIntSafe(d)
+	if err != nil {
+		return LocalDate{}, err
+	}
+	monthDay, err := readMonthDay(d)
+	if err != nil {
+		return LocalDate{}, err
+	}
+	return LocalDate{Year: int(year), Month: monthDay.(MonthDay).Month, Day: monthDay.(MonthDay).Day}, nil
+}
+
+func readLocalDate(d *graphBinaryDecoder) (interface{}, error) {
+	return readLocalDateSafe(d)
+}
+
+func readLocalDateTimeSafe(d *graphBinaryDecoder) (LocalDateTime, error) {
+	date, err := readLocalDateSafe(d)
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+	nanoOfDay, err := readLongSafe(d)
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+	return LocalDateTime{Date: date, Time: localTimeOfNanoOfDay(nanoOfDay)}, nil
+}
+
+func readLocalDateTime(d *graphBinaryDecoder) (interface{}, error) {
+	return readLocalDateTimeSafe(d)
+}
+
+func readLocalTime(d *graphBinaryDecoder) (interface{}, error) {
+	nanoOfDay, err := readLongSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return localTimeOfNanoOfDay(nanoOfDay), nil
+}
+
+func readMonthDay(d *graphBinaryDecoder) (interface{}, error) {
+	b, err := d.next(2)
+	if err != nil {
+		return nil, err
+	}
+	return MonthDay{Month: time.Month(int8(b[0])), Day: int(int8(b[1]))}, nil
+}
+
+// OffsetDateTimes and ZonedDateTimes are read as time.Time in a fixed zone with their offset.
+func readOffsetDateTime(d *graphBinaryDecoder) (interface{}, error) {
+	dt, err := readLocalDateTimeSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return dt.In(ZoneOffset(offset).Location()), nil
+}
+
+func readOffsetTime(d *graphBinaryDecoder) (interface{}, error) {
+	localTime, err := readLocalTime(d)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return OffsetTime{Time: localTime.(LocalTime), Offset: ZoneOffset(offset)}, nil
+}
+
+func readPeriod(d *graphBinaryDecoder) (interface{}, error) {
+	var values [3]int32
+	for j := range values {
+		var err error
+		if values[j], err = readIntSafe(d); err != nil {
+			return nil, err
+		}
+	}
+	return Period{Years: int(values[0]), Months: int(values[1]), Days: int(values[2])}, nil
+}
+
+func readYear(d *graphBinaryDecoder) (interface{}, error) {
+	year, err := readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return Year(year), nil
+}
+
+func readYearMonth(d *graphBinaryDecoder) (interface{}, error) {
+	year, err := readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	month, err := readByteSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return YearMonth{Year: int(year), Month: time.Month(int8(month))}, nil
+}
+
+func readZoneOffset(d *graphBinaryDecoder) (interface{}, error) {
+	offset, err := readIntSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	return ZoneOffset(offset), nil
+}
+
+// skipNullValue skips the type code and value flag of a value which is always null, such as the parent of a property.
+func skipNullValue(d *graphBinaryDecoder) error {
+	return d.skip(2)
+}
+
+// Graph
 
 // {fully qualified id}{unqualified label}
-func vertexReader(data *[]byte, i *int) (interface{}, error) {
-	return vertexReaderReadingProperties(data, i, true)
+func vertexReader(d *graphBinaryDecoder) (interface{}, error) {
+	return vertexReaderReadingProperties(d, true)
 }
 
 // {fully qualified id}{unqualified label}{fully qualified properties}
-func vertexReaderReadingProperties(data *[]byte, i *int, readProperties bool) (interface{}, error) {
+func vertexReaderReadingProperties(d *graphBinaryDecoder, readProperties bool) (interface{}, error) {
 	var err error
 	v := new(Vertex)
-	v.Id, err = readFullyQualifiedNullable(data, i, true)
+	v.Id, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	label, err := readUnqualified(data, i, stringType, false)
+	label, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	v.Label = label.(string)
 	if readProperties {
-		v.Properties, err = readFullyQualifiedNullable(data, i, true)
+		v.Properties, err = readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
@@ -1076,30 +1777,32 @@ func vertexReaderReadingProperties(data *[]byte, i *int, readProperties bool) (i
 }
 
 // {fully qualified id}{unqualified label}{in vertex w/o null byte}{out vertex}{unused null byte}{fully qualified properties}
-func edgeReader(data *[]byte, i *int) (interface{}, error) {
+func edgeReader(d *graphBinaryDecoder) (interface{}, error) {
 	var err error
 	e := new(Edge)
-	e.Id, err = readFullyQualifiedNullable(data, i, true)
+	e.Id, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	label, err := readUnqualified(data, i, stringType, false)
+	label, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	e.Label = label.(string)
-	v, err := vertexReaderReadingProperties(data, i, false)
+	v, err := vertexReaderReadingProperties(d, false)
 	if err != nil {
 		return nil, err
 	}
 	e.InV = *v.(*Vertex)
-	v, err = vertexReaderReadingProperties(data, i, false)
+	v, err = vertexReaderReadingProperties(d, false)
 	if err != nil {
 		return nil, err
 	}
 	e.OutV = *v.(*Vertex)
-	*i += 2
-	e.Properties, err = readFullyQualifiedNullable(data, i, true)
+	if err = skipNullValue(d); err != nil {
+		return nil, err
+	}
+	e.Properties, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1107,42 +1810,46 @@ func edgeReader(data *[]byte, i *int) (interface{}, error) {
 }
 
 // {unqualified key}{fully qualified value}{null byte}
-func propertyReader(data *[]byte, i *int) (interface{}, error) {
+func propertyReader(d *graphBinaryDecoder) (interface{}, error) {
 	p := new(Property)
-	key, err := readUnqualified(data, i, stringType, false)
+	key, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	p.Key = key.(string)
-	p.Value, err = readFullyQualifiedNullable(data, i, true)
+	p.Value, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	*i += 2
+	if err = skipNullValue(d); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
 // {fully qualified id}{unqualified label}{fully qualified value}{null byte}{null byte}
-func vertexPropertyReader(data *[]byte, i *int) (interface{}, error) {
+func vertexPropertyReader(d *graphBinaryDecoder) (interface{}, error) {
 	var err error
 	vp := new(VertexProperty)
-	vp.Id, err = readFullyQualifiedNullable(data, i, true)
+	vp.Id, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	label, err := readUnqualified(data, i, stringType, false)
+	label, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	vp.Label = label.(string)
-	vp.Value, err = readFullyQualifiedNullable(data, i, true)
+	vp.Value, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
 
-	*i += 2
+	if err = skipNullValue(d); err != nil {
+		return nil, err
+	}
 
-	props, err := readFullyQualifiedNullable(data, i, true)
+	props, err := readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1153,29 +1860,168 @@ func vertexPropertyReader(data *[]byte, i *int) (interface{}, error) {
 }
 
 // {list of set of strings}{list of fully qualified objects}
-func pathReader(data *[]byte, i *int) (interface{}, error) {
+func pathReader(d *graphBinaryDecoder) (interface{}, error) {
 	path := new(Path)
-	newLabels, err := readFullyQualifiedNullable(data, i, true)
+	newLabels, err := readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	for _, param := range newLabels.([]interface{}) {
-		path.Labels = append(path.Labels, param.(*SimpleSet))
+	labels, ok := newLabels.([]interface{})
+	if !ok {
+		return nil, newError(E0414ReadUnexpectedValueError, "list of labels", newLabels)
+	}
+	for _, param := range labels {
+		set, ok := param.(*SimpleSet)
+		if !ok {
+			return nil, newError(E0414ReadUnexpectedValueError, "set of labels", param)
+		}
+		path.Labels = append(path.Labels, set)
 	}
-	objects, err := readFullyQualifiedNullable(data, i, true)
+	objects, err := readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
-	path.Objects = objects.([]interface{})
+	path.Objects, ok = objects.([]interface{})
+	if !ok {
+		return nil, newError(E0414ReadUnexpectedValueError, "list of objects", objects)
+	}
 	return path, err
 }
 
+// {vertex_length}{vertex_0}...{vertex_n}{edge_length}{edge_0}...{edge_n}
+func graphReader(d *graphBinaryDecoder) (interface{}, error) {
+	g := NewGraph()
+	vertexCount, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	for j := 0; j < vertexCount; j++ {
+		v := new(Vertex)
+		v.Id, err = readFullyQualifiedNullable(d, true)
+		if err != nil {
+			return nil, err
+		}
+		label, err := readUnqualified(d, stringType, false)
+		if err != nil {
+			return nil, err
+		}
+		v.Label = label.(string)
+
+		propertyCount, err := readLength(d)
+		if err != nil {
+			return nil, err
+		}
+		properties := make([]interface{}, 0)
+		for k := 0; k < propertyCount; k++ {
+			vp := new(VertexProperty)
+			vp.Id, err = readFullyQualifiedNullable(d, true)
+			if err != nil {
+				return nil, err
+			}
+			label, err = readUnqualified(d, stringType, false)
+			if err != nil {
+				return nil, err
+			}
+			vp.Label = label.(string)
+			vp.Key = vp.Label
+			vp.Value, err = readFullyQualifiedNullable(d, true)
+			if err != nil {
+				return nil, err
+			}
+			// The parent is always null.
+			if err = skipNullValue(d); err != nil {
+				return nil, err
+			}
+			vp.Properties, err = readUnqualified(d, listType, false)
+			if err != nil {
+				return nil, err
+			}
+			vp.Vertex = Vertex{Element{Id: v.Id, Label: v.Label}}
+			properties = append(properties, vp)
+		}
+		v.Properties = properties
+		g.AddVertex(v)
+	}
+
+	edgeCount, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	for j := 0; j < edgeCount; j++ {
+		e := new(Edge)
+		e.Id, err = readFullyQualifiedNullable(d, true)
+		if err != nil {
+			return nil, err
+		}
+		label, err := readUnqualified(d, stringType, false)
+		if err != nil {
+			return nil, err
+		}
+		e.Label = label.(string)
+		// The labels of the vertices are always null, so they are taken from the vertices of the graph.
+		e.InV.Id, err = readFullyQualifiedNullable(d, true)
+		if err != nil {
+			return nil, err
+		}
+		if err = skipNullValue(d); err != nil {
+			return nil, err
+		}
+		e.OutV.Id, err = readFullyQualifiedNullable(d, true)
+		if err != nil {
+			return nil, err
+		}
+		// The label of the out vertex and the parent are always null.
+		for k := 0; k < 2; k++ {
+			if err = skipNullValue(d); err != nil {
+				return nil, err
+			}
+		}
+		e.Properties, err = readUnqualified(d, listType, false)
+		if err != nil {
+			return nil, err
+		}
+		if v := g.Vertex(e.InV.Id); v != nil {
+			e.InV.Label = v.Label
+		}
+		if v := g.Vertex(e.OutV.Id); v != nil {
+			e.OutV.Label = v.Label
+		}
+		g.AddEdge(e)
+	}
+	return g, nil
+}
+
+// {length}{item_0}...{item_n}, where each item is a fully qualified key followed by a subtree without type info or
+// value flag.
+func treeReader(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	tree := new(Tree)
+	for j := 0; j < sz; j++ {
+		key, err := readFullyQualifiedNullable(d, true)
+		if err != nil {
+			return nil, err
+		}
+		subtree, err := treeReader(d)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, &TreeNode{Key: key, Tree: *subtree.(*Tree)})
+	}
+	return tree, nil
+}
+
 // {bulk int}{fully qualified value}
-func traverserReader(data *[]byte, i *int) (interface{}, error) {
+func traverserReader(d *graphBinaryDecoder) (interface{}, error) {
 	var err error
 	traverser := new(Traverser)
-	traverser.bulk = readLongSafe(data, i)
-	traverser.value, err = readFullyQualifiedNullable(data, i, true)
+	traverser.bulk, err = readLongSafe(d)
+	if err != nil {
+		return nil, err
+	}
+	traverser.value, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1183,42 +2029,51 @@ func traverserReader(data *[]byte, i *int) (interface{}, error) {
 }
 
 // {int32 length}{fully qualified item_0}{int64 repetition_0}...{fully qualified item_n}{int64 repetition_n}
-func bulkSetReader(data *[]byte, i *int) (interface{}, error) {
-	sz := int(readIntSafe(data, i))
-	var valList []interface{}
+func bulkSetReader(d *graphBinaryDecoder) (interface{}, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	bulkSet := NewBulkSet()
 	for j := 0; j < sz; j++ {
-		val, err := readFullyQualifiedNullable(data, i, true)
+		val, err := readFullyQualifiedNullable(d, true)
 		if err != nil {
 			return nil, err
 		}
-		rep := readLongSafe(data, i)
-		for k := 0; k < int(rep); k++ {
-			valList = append(valList, val)
+		bulk, err := readLongSafe(d)
+		if err != nil {
+			return nil, err
 		}
+		bulkSet.add(val, bulk)
 	}
-	return valList, nil
+	return bulkSet, nil
 }
 
 // {type code (always string so ignore)}{nil code (always false so ignore)}{int32 size}{string enum}
-func enumReader(data *[]byte, i *int) (interface{}, error) {
-	typeCode := readDataType(data, i)
+func enumReader(d *graphBinaryDecoder) (interface{}, error) {
+	typeCode, err := readDataType(d)
+	if err != nil {
+		return nil, err
+	}
 	if typeCode != stringType {
-		return nil, newError(err0406EnumReaderInvalidTypeError)
+		return nil, newError(E0406EnumReaderInvalidTypeError)
 	}
-	*i++
-	return readString(data, i)
+	if err = d.skip(1); err != nil {
+		return nil, err
+	}
+	return readString(d)
 }
 
 // {unqualified key}{fully qualified value}
-func bindingReader(data *[]byte, i *int) (interface{}, error) {
+func bindingReader(d *graphBinaryDecoder) (interface{}, error) {
 	b := new(Binding)
-	val, err := readUnqualified(data, i, stringType, false)
+	val, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	b.Key = val.(string)
 
-	b.Value, err = readFullyQualifiedNullable(data, i, true)
+	b.Value, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1226,88 +2081,194 @@ func bindingReader(data *[]byte, i *int) (interface{}, error) {
 }
 
 // {id}{name}{duration}{counts}{annotations}{nested_metrics}
-func metricsReader(data *[]byte, i *int) (interface{}, error) {
+func metricsReader(d *graphBinaryDecoder) (interface{}, error) {
 	metrics := new(Metrics)
-	val, err := readUnqualified(data, i, stringType, false)
+	val, err := readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	metrics.Id = val.(string)
 
-	val, err = readUnqualified(data, i, stringType, false)
+	val, err = readUnqualified(d, stringType, false)
 	if err != nil {
 		return nil, err
 	}
 	metrics.Name = val.(string)
 
-	dur, err := readLong(data, i)
+	dur, err := readLong(d)
 	if err != nil {
 		return nil, err
 	}
 	metrics.Duration = dur.(int64)
 
-	counts, err := readMap(data, i)
-	cmap := counts.(map[interface{}]interface{})
+	counts, err := readMap(d)
 	if err != nil {
 		return nil, err
 	}
+	cmap := counts.(map[interface{}]interface{})
 	metrics.Counts = make(map[string]int64, len(cmap))
 	for k := range cmap {
-		metrics.Counts[k.(string)] = cmap[k].(int64)
+		count, ok := cmap[k].(int64)
+		if !ok {
+			return nil, newError(E0414ReadUnexpectedValueError, "long count", cmap[k])
+		}
+		metrics.Counts[fmt.Sprint(k)] = count
 	}
 
-	annotations, err := readMap(data, i)
+	annotations, err := readMap(d)
 	if err != nil {
 		return nil, err
 	}
 	amap := annotations.(map[interface{}]interface{})
-	if err != nil {
-		return nil, err
-	}
 	metrics.Annotations = make(map[string]interface{}, len(amap))
 	for k := range amap {
-		metrics.Annotations[k.(string)] = amap[k]
+		metrics.Annotations[fmt.Sprint(k)] = amap[k]
 	}
 
-	nested, err := readList(data, i)
+	nested, err := readList(d)
 	if err != nil {
 		return nil, err
 	}
-	list := nested.([]interface{})
-	metrics.NestedMetrics = make([]Metrics, len(list))
-	for i, metric := range list {
-		metrics.NestedMetrics[i] = metric.(Metrics)
+	metrics.NestedMetrics, err = readMetricsList(nested)
+	if err != nil {
+		return nil, err
 	}
 
 	return metrics, nil
 }
 
+// readMetricsList converts a list that was read into Metrics.
+func readMetricsList(list interface{}) ([]Metrics, error) {
+	values := list.([]interface{})
+	metrics := make([]Metrics, len(values))
+	for i, metric := range values {
+		m, ok := metric.(*Metrics)
+		if !ok {
+			return nil, newError(E0414ReadUnexpectedValueError, "metrics", metric)
+		}
+		metrics[i] = *m
+	}
+	return metrics, nil
+}
+
 // {id}{name}{duration}{counts}{annotations}{nested_metrics}
-func traversalMetricsReader(data *[]byte, i *int) (interface{}, error) {
+func traversalMetricsReader(d *graphBinaryDecoder) (interface{}, error) {
 	m := new(TraversalMetrics)
-	dur, err := readLong(data, i)
+	dur, err := readLong(d)
 	if err != nil {
 		return nil, err
 	}
 	m.Duration = dur.(int64)
 
-	nested, err := readList(data, i)
+	nested, err := readList(d)
 	if err != nil {
 		return nil, err
 	}
-	list := nested.([]interface{})
-	m.Metrics = make([]Metrics, len(list))
-	for i, metric := range list {
-		m.Metrics[i] = *metric.(*Metrics)
+	m.Metrics, err = readMetricsList(nested)
+	if err != nil {
+		return nil, err
 	}
 
 	return m, nil
 }
 
+// {steps_length}{step_0}...{step_n}{sources_length}{source_0}...{source_n}
+func bytecodeReader(d *graphBinaryDecoder) (interface{}, error) {
+	bc := NewBytecode(nil)
+	var err error
+	bc.stepInstructions, err = instructionReader(d)
+	if err != nil {
+		return nil, err
+	}
+	bc.sourceInstructions, err = instructionReader(d)
+	if err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// {name}{values_length}{value_0}...{value_n} for each instruction.
+func instructionReader(d *graphBinaryDecoder) ([]instruction, error) {
+	sz, err := readLength(d)
+	if err != nil {
+		return nil, err
+	}
+	instructions := make([]instruction, 0, preallocatedLength(sz))
+	for j := 0; j < sz; j++ {
+		operator, err := readString(d)
+		if err != nil {
+			return nil, err
+		}
+		arguments, err := readList(d)
+		if err != nil {
+			return nil, err
+		}
+		args, _ := arguments.([]interface{})
+		instructions = append(instructions, instruction{operator: operator.(string), arguments: args})
+	}
+	return instructions, nil
+}
+
+// {name}{values_length}{value_0}...{value_n}
+func pReader(d *graphBinaryDecoder) (interface{}, error) {
+	operator, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	values, err := readList(d)
+	if err != nil {
+		return nil, err
+	}
+	args, _ := values.([]interface{})
+	return &p{operator: operator.(string), values: args}, nil
+}
+
+func textPReader(d *graphBinaryDecoder) (interface{}, error) {
+	predicate, err := pReader(d)
+	if err != nil {
+		return nil, err
+	}
+	return (*textP)(predicate.(*p)), nil
+}
+
+// {language}{script}{arguments_length}
+func lambdaReader(d *graphBinaryDecoder) (interface{}, error) {
+	language, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	script, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	// The number of arguments is not kept, as lambdas are not written with it.
+	if _, err = readIntSafe(d); err != nil {
+		return nil, err
+	}
+	return &Lambda{Script: script.(string), Language: language.(string)}, nil
+}
+
+// {strategy_class}{configuration}
+func traversalStrategyReader(d *graphBinaryDecoder) (interface{}, error) {
+	name, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	configuration, err := readMap(d)
+	if err != nil {
+		return nil, err
+	}
+	strategy := &traversalStrategy{name: name.(string), configuration: map[string]interface{}{}}
+	for k, v := range configuration.(map[interface{}]interface{}) {
+		strategy.configuration[fmt.Sprint(k)] = v
+	}
+	return strategy, nil
+}
+
 // Format: A String containing the fqcn.
-func readClass(data *[]byte, i *int) (interface{}, error) {
+func readClass(d *graphBinaryDecoder) (interface{}, error) {
 	gremlinType := new(GremlinType)
-	str, err := readString(data, i)
+	str, err := readString(d)
 	if err != nil {
 		return nil, err
 	}
@@ -1316,32 +2277,64 @@ func readClass(data *[]byte, i *int) (interface{}, error) {
 	return gremlinType, nil
 }
 
-func readUnqualified(data *[]byte, i *int, dataTyp dataType, nullable bool) (interface{}, error) {
-	if nullable && readByteSafe(data, i) == valueFlagNull {
-		return getDefaultValue(dataTyp), nil
+func readUnqualified(d *graphBinaryDecoder, dataTyp dataType, nullable bool) (interface{}, error) {
+	start := d.offset
+	if nullable {
+		valueFlag, err := readByteSafe(d)
+		if err != nil {
+			return nil, decodingError(err, start, dataTyp)
+		}
+		if valueFlag == valueFlagNull {
+			return getDefaultValue(dataTyp), nil
+		}
 	}
-	deserializer, ok := deserializers[dataTyp]
+	deserializer, ok := d.deserializer(dataTyp)
 	if !ok {
-		return nil, newError(err0408GetSerializerToReadUnknownTypeError, dataTyp)
+		return nil, decodingError(newError(E0408GetSerializerToReadUnknownTypeError, dataTyp), start, dataTyp)
+	}
+	val, err := deserializer(d)
+	if err != nil {
+		return nil, decodingError(err, d.offset, dataTyp)
 	}
-	return deserializer(data, i)
+	return val, nil
 }
 
-func readFullyQualifiedNullable(data *[]byte, i *int, nullable bool) (interface{}, error) {
-	dataTyp := readDataType(data, i)
-	if dataTyp == nullType {
-		if readByteSafe(data, i) != valueFlagNull {
-			return nil, newError(err0404ReadNullTypeError)
+func readFullyQualifiedNullable(d *graphBinaryDecoder, nullable bool) (interface{}, error) {
+	start := d.offset
+	dataTyp, err := readDataType(d)
+	if err != nil {
+		// The error is reported for the type of the value that holds this one.
+		return nil, err
+	}
+	if dataTyp == customType {
+		val, err := readCustomType(d, nullable)
+		if err != nil {
+			return nil, decodingError(err, d.offset, dataTyp)
 		}
-		return nil, nil
-	} else if nullable {
-		if readByteSafe(data, i) == valueFlagNull {
+		return val, nil
+	}
+	if dataTyp == nullType || nullable {
+		valueFlag, err := readByteSafe(d)
+		if err != nil {
+			return nil, decodingError(err, d.offset, dataTyp)
+		}
+		if dataTyp == nullType {
+			if valueFlag != valueFlagNull {
+				return nil, decodingError(newError(E0404ReadNullTypeError), start, dataTyp)
+			}
+			return nil, nil
+		}
+		if valueFlag == valueFlagNull {
 			return getDefaultValue(dataTyp), nil
 		}
 	}
-	deserializer, ok := deserializers[dataTyp]
+	deserializer, ok := d.deserializer(dataTyp)
 	if !ok {
-		return nil, newError(err0408GetSerializerToReadUnknownTypeError, dataTyp)
+		return nil, decodingError(newError(E0408GetSerializerToReadUnknownTypeError, dataTyp), start, dataTyp)
+	}
+	val, err := deserializer(d)
+	if err != nil {
+		return nil, decodingError(err, d.offset, dataTyp)
 	}
-	return deserializer(data, i)
+	return val, nil
 }
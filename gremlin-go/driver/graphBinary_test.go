@@ -22,15 +22,23 @@ package gremlingo
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 	"math/big"
+	"net"
+	"os"
 	"reflect"
 	"testing"
 	"time"
 )
 
+// newTestDecoder returns a decoder of data.
+func newTestDecoder(data []byte) *graphBinaryDecoder {
+	return newGraphBinaryDecoder(bytes.NewReader(data))
+}
+
 func TestGraphBinaryV1(t *testing.T) {
 	t.Run("graphBinaryTypeSerializer tests", func(t *testing.T) {
 		serializer := graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
@@ -74,27 +82,24 @@ func TestGraphBinaryV1(t *testing.T) {
 
 	t.Run("read-write tests", func(t *testing.T) {
 		t.Run("read-write string", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			str := "test string"
 			buf, err := stringWriter(str, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readString(&buf, &pos)
+			res, err := readString(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, str, res)
 		})
 		t.Run("read-write GremlinType", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := &GremlinType{"test fqcn"}
 			buf, err := classWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readClass(&buf, &pos)
+			res, err := readClass(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write bool", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			f := func(value interface{}, buffer *bytes.Buffer, typeSerializer *graphBinaryTypeSerializer) ([]byte, error) {
 				err := binary.Write(buffer, binary.BigEndian, value.(bool))
@@ -102,155 +107,347 @@ func TestGraphBinaryV1(t *testing.T) {
 			}
 			data, err := f(false, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readBoolean(&data, &pos)
+			res, err := readBoolean(newTestDecoder(data))
 			assert.Nil(t, err)
 			assert.False(t, res.(bool))
 
 			data, err = f(true, &buffer, nil)
 			assert.Nil(t, err)
-			res, err = readBoolean(&data, &pos)
+			res, err = readBoolean(newTestDecoder(data[1:]))
 			assert.Nil(t, err)
 			assert.True(t, res.(bool))
 		})
 		t.Run("read-write BigDecimal", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := &BigDecimal{11, *big.NewInt(int64(22))}
 			buf, err := bigDecimalWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readBigDecimal(&buf, &pos)
+			res, err := readBigDecimal(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write int", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := int32(123)
 			buf, err := intWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readInt(&buf, &pos)
+			res, err := readInt(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write short", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := int16(123)
 			buf, err := shortWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readShort(&buf, &pos)
+			res, err := readShort(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write short int8", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := int8(123)
 			buf, err := shortWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readShort(&buf, &pos)
+			res, err := readShort(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, int16(source), res)
 		})
 		t.Run("read-write long", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := 123
 			buf, err := longWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readLong(&buf, &pos)
+			res, err := readLong(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, int64(source), res)
 		})
 		t.Run("read-write bigInt", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := big.NewInt(123)
 			buf, err := bigIntWriter(*source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readBigInt(&buf, &pos)
+			res, err := readBigInt(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write bigInt uint64", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := uint64(123)
 			buf, err := bigIntWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readBigInt(&buf, &pos)
+			res, err := readBigInt(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, new(big.Int).SetUint64(source), res)
 		})
 		t.Run("read-write bigInt uint64", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := uint(123)
 			buf, err := bigIntWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readBigInt(&buf, &pos)
+			res, err := readBigInt(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, new(big.Int).SetUint64(uint64(source)), res)
 		})
 		t.Run("read-write list", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := []interface{}{int32(111), "str"}
 			buf, err := listWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readList(&buf, &pos)
+			res, err := readList(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write byteBuffer", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := &ByteBuffer{[]byte{byte(127), byte(255)}}
 			buf, err := byteBufferWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readByteBuffer(&buf, &pos)
+			res, err := readByteBuffer(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write set", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := NewSimpleSet(int32(111), "str")
 			buf, err := setWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readSet(&buf, &pos)
+			res, err := readSet(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
 		t.Run("read-write map", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := map[interface{}]interface{}{1: "s1", "s2": 2, nil: nil}
 			buf, err := mapWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := readMap(&buf, &pos)
+			res, err := readMap(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, fmt.Sprintf("%v", source), fmt.Sprintf("%v", res))
 		})
 		t.Run("read-write time", func(t *testing.T) {
-			pos := 0
 			var buffer bytes.Buffer
 			source := time.Date(2022, 5, 10, 9, 51, 0, 0, time.Local)
 			buf, err := timeWriter(source, &buffer, nil)
 			assert.Nil(t, err)
-			res, err := timeReader(&buf, &pos)
+			res, err := timeReader(newTestDecoder(buf))
+			assert.Nil(t, err)
+			assert.Equal(t, source, res)
+		})
+		t.Run("read-write tree", func(t *testing.T) {
+			var buffer bytes.Buffer
+			source := newTestTree()
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			buf, err := treeWriter(source, &buffer, serializer)
+			assert.Nil(t, err)
+			decoder := newTestDecoder(buf)
+			res, err := treeReader(decoder)
+			assert.Nil(t, err)
+			assert.Equal(t, len(buf), decoder.offset)
+			assert.Equal(t, source, res)
+		})
+		t.Run("read-write graph", func(t *testing.T) {
+			// The crew graph as written by gremlin-core, taken from gremlin-io-test.
+			data, err := os.ReadFile("testdata/tinkergraph-v1.gbin")
+			assert.Nil(t, err)
+			decoder := newTestDecoder(data)
+			res, err := readFullyQualifiedNullable(decoder, true)
+			assert.Nil(t, err)
+			assert.Equal(t, len(data), decoder.offset)
+			g, ok := res.(*Graph)
+			assert.True(t, ok)
+			assert.Len(t, g.Vertices(), 6)
+			assert.Len(t, g.Edges(), 14)
+
+			marko := g.Vertex(int32(1))
+			assert.Equal(t, "person", marko.Label)
+			properties := marko.Properties.([]interface{})
+			assert.Len(t, properties, 5)
+			name := properties[0].(*VertexProperty)
+			assert.Equal(t, int64(0), name.Id)
+			assert.Equal(t, "marko", name.Value)
+			assert.Empty(t, name.Properties)
+			location := properties[1].(*VertexProperty)
+			assert.Equal(t, "location", location.Key)
+			assert.Equal(t, "san diego", location.Value)
+			assert.Equal(t, []interface{}{&Property{Key: "startTime", Value: int32(1997)},
+				&Property{Key: "endTime", Value: int32(2001)}}, location.Properties)
+
+			develops := g.Edge(int32(13))
+			assert.Equal(t, "develops", develops.Label)
+			assert.Equal(t, "person", develops.OutV.Label)
+			assert.Equal(t, "software", develops.InV.Label)
+			assert.Equal(t, []interface{}{&Property{Key: "since", Value: int32(2009)}}, develops.Properties)
+
+			var buffer bytes.Buffer
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			buf, err := serializer.write(g, &buffer)
+			assert.Nil(t, err)
+			assert.Equal(t, data, buf)
+		})
+		t.Run("read-write bulkSet", func(t *testing.T) {
+			var buffer bytes.Buffer
+			source := NewBulkSet()
+			source.Add("a", 2)
+			source.Add(int32(1), 5000000000)
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			buf, err := bulkSetWriter(source, &buffer, serializer)
+			assert.Nil(t, err)
+			res, err := bulkSetReader(newTestDecoder(buf))
+			assert.Nil(t, err)
+			assert.Equal(t, source, res)
+		})
+		t.Run("read-write traverser", func(t *testing.T) {
+			var buffer bytes.Buffer
+			source := &Traverser{bulk: 3, value: "marko"}
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			buf, err := traverserWriter(source, &buffer, serializer)
+			assert.Nil(t, err)
+			res, err := traverserReader(newTestDecoder(buf))
 			assert.Nil(t, err)
 			assert.Equal(t, source, res)
 		})
+		t.Run("read-write extended types", func(t *testing.T) {
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			roundTrip := func(value interface{}) interface{} {
+				var buffer bytes.Buffer
+				buf, err := serializer.write(value, &buffer)
+				assert.Nil(t, err)
+				data := buf.([]byte)
+				decoder := newTestDecoder(data)
+				res, err := readFullyQualifiedNullable(decoder, true)
+				assert.Nil(t, err)
+				assert.Equal(t, len(data), decoder.offset)
+				return res
+			}
+			localDate := LocalDate{Year: -2007, Month: time.December, Day: 31}
+			localTime := LocalTime{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999999999}
+			for _, value := range []interface{}{
+				localDate,
+				localTime,
+				LocalDateTime{Date: localDate, Time: localTime},
+				MonthDay{Month: time.February, Day: 29},
+				OffsetTime{Time: localTime, Offset: -3600},
+				Period{Years: 1, Months: -2, Days: 3},
+				Year(2018),
+				YearMonth{Year: 2007, Month: time.December},
+				ZoneOffset(5*3600 + 30*60),
+			} {
+				assert.Equal(t, value, roundTrip(value))
+			}
+			for _, char := range []rune{'a', '¢', '€', '𐍈'} {
+				assert.Equal(t, char, roundTrip(Char(char)))
+			}
+			assert.Equal(t, net.IP{192, 168, 0, 1}, roundTrip(net.ParseIP("192.168.0.1")))
+			assert.Equal(t, net.ParseIP("2001:db8::1"), roundTrip(net.ParseIP("2001:db8::1")))
+
+			instant := time.Date(1969, time.July, 20, 20, 17, 40, 123456789, time.UTC)
+			assert.Equal(t, instant, roundTrip(Instant{instant.In(time.Local)}))
+			offsetDateTime := time.Date(2007, time.December, 3, 10, 15, 30, 5, time.FixedZone("", -7*3600))
+			for _, value := range []interface{}{OffsetDateTime{offsetDateTime}, ZonedDateTime{offsetDateTime}} {
+				res := roundTrip(value).(time.Time)
+				assert.True(t, offsetDateTime.Equal(res))
+				assert.Equal(t, "2007-12-03T10:15:30.000000005-07:00", res.Format(time.RFC3339Nano))
+			}
+		})
+		t.Run("write extended types format", func(t *testing.T) {
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			for _, testCase := range []struct {
+				value    interface{}
+				expected []byte
+			}{
+				{Char('€'), []byte{0x80, 0x00, 0xe2, 0x82, 0xac}},
+				{net.IP{10, 0, 0, 1}, []byte{0x82, 0x00, 0x00, 0x00, 0x00, 0x04, 10, 0, 0, 1}},
+				{LocalDate{Year: 2007, Month: time.December, Day: 3}, []byte{0x84, 0x00, 0x00, 0x00, 0x07, 0xd7, 0x0c, 0x03}},
+				{LocalTime{Second: 1}, []byte{0x86, 0x00, 0x00, 0x00, 0x00, 0x00, 0x3b, 0x9a, 0xca, 0x00}},
+				{Year(2018), []byte{0x8b, 0x00, 0x00, 0x00, 0x07, 0xe2}},
+				{ZoneOffset(-1), []byte{0x8e, 0x00, 0xff, 0xff, 0xff, 0xff}},
+			} {
+				var buffer bytes.Buffer
+				buf, err := serializer.write(testCase.value, &buffer)
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expected, buf)
+			}
+			var buffer bytes.Buffer
+			_, err := serializer.write(net.IP{1, 2, 3}, &buffer)
+			assert.True(t, isSameErrorCode(newError(E0410WriteInvalidInetAddressError), err))
+		})
+		t.Run("read-write tree fully qualified", func(t *testing.T) {
+			var buffer bytes.Buffer
+			source := Tree{Children: []*TreeNode{{Key: "a", Tree: Tree{Children: []*TreeNode{{Key: int32(1)}}}}, {Key: "b"}}}
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			buf, err := serializer.write(source, &buffer)
+			assert.Nil(t, err)
+			data := buf.([]byte)
+			assert.Equal(t, byte(treeType), data[0])
+			res, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+			assert.Nil(t, err)
+			assert.Equal(t, &source, res)
+			assert.Equal(t, []interface{}{int32(1), "b"}, res.(*Tree).LeafObjects())
+		})
 	})
 
 	t.Run("error handle tests", func(t *testing.T) {
 		t.Run("test map key not string failure", func(t *testing.T) {
-			i := 0
 			buff := []byte{0x00, 0x00, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x01}
-			m, err := readMapUnqualified(&buff, &i)
+			m, err := readMapUnqualified(newTestDecoder(buff))
 			assert.Nil(t, m)
-			assert.Equal(t, newError(err0703ReadMapNonStringKeyError), err)
+			assert.Equal(t, newError(E0703ReadMapNonStringKeyError), err)
+		})
+		t.Run("test truncated data failure", func(t *testing.T) {
+			var buffer bytes.Buffer
+			serializer := &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			source := []interface{}{newTestGraph(), newTestTree(), map[interface{}]interface{}{"a": LocalDate{2023, 4, 5}},
+				big.NewInt(-12345), &Traverser{bulk: 2, value: net.IP{127, 0, 0, 1}}}
+			buf, err := serializer.write(source, &buffer)
+			assert.Nil(t, err)
+			data := buf.([]byte)
+			for end := 0; end < len(data); end++ {
+				truncated := data[:end]
+				res, err := readFullyQualifiedNullable(newTestDecoder(truncated), true)
+				assert.Nil(t, res)
+				assert.NotNil(t, err)
+			}
+		})
+		t.Run("test truncated data decoding error", func(t *testing.T) {
+			// A list holding a single int of which only two bytes are left.
+			data := []byte{byte(listType), 0x00, 0x00, 0x00, 0x00, 0x01, byte(intType), 0x00, 0x00, 0x01}
+			res, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+			assert.Nil(t, res)
+			var decodingError *DecodingError
+			assert.True(t, errors.As(err, &decodingError))
+			assert.Equal(t, byte(intType), decodingError.TypeCode)
+			assert.Equal(t, 8, decodingError.Offset)
+			assert.True(t, isSameErrorCode(newError(E0412ReadTruncatedDataError), err))
+		})
+		t.Run("test invalid length failure", func(t *testing.T) {
+			data := []byte{byte(listType), 0x00, 0xff, 0xff, 0xff, 0xff}
+			res, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+			assert.Nil(t, res)
+			var decodingError *DecodingError
+			assert.True(t, errors.As(err, &decodingError))
+			assert.Equal(t, byte(listType), decodingError.TypeCode)
+			assert.True(t, isSameErrorCode(newError(E0413ReadInvalidLengthError), err))
+		})
+		t.Run("test length exceeding data failure", func(t *testing.T) {
+			for _, dataTyp := range []dataType{listType, mapType, stringType, byteBuffer, bigIntegerType} {
+				data := []byte{byte(dataTyp), 0x00, 0x7f, 0xff, 0xff, 0xff, 0x00}
+				res, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+				assert.Nil(t, res)
+				var decodingError *DecodingError
+				assert.True(t, errors.As(err, &decodingError))
+				assert.True(t, isSameErrorCode(newError(E0412ReadTruncatedDataError), err))
+			}
+		})
+		t.Run("test unexpected value failure", func(t *testing.T) {
+			// A path of which the labels are null.
+			data := []byte{byte(pathType), 0x00, byte(listType), 0x01, byte(listType), 0x00, 0x00, 0x00, 0x00, 0x00}
+			res, err := readFullyQualifiedNullable(newTestDecoder(data), true)
+			assert.Nil(t, res)
+			var decodingError *DecodingError
+			assert.True(t, errors.As(err, &decodingError))
+			assert.Equal(t, byte(pathType), decodingError.TypeCode)
+			assert.True(t, isSameErrorCode(newError(E0414ReadUnexpectedValueError), err))
 		})
 	})
 }
@@ -0,0 +1,1125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const graphSONV3MimeType = "application/vnd.gremlin-v3.0+json"
+
+const (
+	graphSONTypeKey  = "@type"
+	graphSONValueKey = "@value"
+)
+
+// graphSONSerializer serializes/deserializes message to/from GraphSON 3.0.
+type graphSONSerializer struct {
+	ser *graphSONTypeSerializer
+}
+
+// graphSONTypeSerializer converts values to and from their GraphSON 3.0 representation.
+type graphSONTypeSerializer struct {
+	logHandler *logHandler
+}
+
+// graphSONReader converts the @value of a typed GraphSON value.
+type graphSONReader func(value interface{}) (interface{}, error)
+
+var graphSONReaders map[string]graphSONReader
+
+func newGraphSONSerializer(handler *logHandler) serializer {
+	return graphSONSerializer{&graphSONTypeSerializer{handler}}
+}
+
+// serializeMessage serializes a request message into GraphSON, preceded by the mime type header.
+func (gs graphSONSerializer) serializeMessage(request *request) ([]byte, error) {
+	args := make(map[string]interface{}, len(request.args))
+	for k, v := range request.args {
+		value, err := gs.ser.write(v)
+		if err != nil {
+			return nil, err
+		}
+		args[k] = value
+	}
+	message := map[string]interface{}{
+		"requestId": typedGraphSON("g:UUID", request.requestID.String()),
+		"op":        request.op,
+		"processor": request.processor,
+		"args":      args,
+	}
+
+	buffer := bytes.Buffer{}
+	buffer.WriteByte(byte(len(graphSONV3MimeType)))
+	buffer.WriteString(graphSONV3MimeType)
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(message); err != nil {
+		return nil, err
+	}
+	// Drop the newline written by Encode.
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// graphSONResponse is the envelope of a response message in GraphSON.
+type graphSONResponse struct {
+	RequestID interface{} `json:"requestId"`
+	Status    struct {
+		Message    string      `json:"message"`
+		Code       uint16      `json:"code"`
+		Attributes interface{} `json:"attributes"`
+	} `json:"status"`
+	Result struct {
+		Data interface{} `json:"data"`
+		Meta interface{} `json:"meta"`
+	} `json:"result"`
+}
+
+// deserializeMessage deserializes a response message, which is decoded while it is read from message.
+func (gs graphSONSerializer) deserializeMessage(message io.Reader) (response, error) {
+	var msg response
+
+	var envelope graphSONResponse
+	decoder := json.NewDecoder(message)
+	// Numbers are kept as json.Number, so that BigInteger and BigDecimal do not lose precision.
+	decoder.UseNumber()
+	if err := decoder.Decode(&envelope); err != nil {
+		if err == io.EOF {
+			gs.ser.logHandler.log(Error, nullInput)
+			return msg, newError(E0405ReadValueInvalidNullInputError)
+		}
+		return msg, err
+	}
+
+	requestID, err := readGraphSON(envelope.RequestID)
+	if err != nil {
+		return msg, err
+	}
+	switch id := requestID.(type) {
+	case uuid.UUID:
+		msg.responseID = id
+	case string:
+		msg.responseID, err = uuid.Parse(id)
+		if err != nil {
+			return msg, err
+		}
+	default:
+		return msg, newError(E1203GraphSONReadInvalidValueError, "requestId", envelope.RequestID)
+	}
+	msg.responseStatus.code = envelope.Status.Code
+	msg.responseStatus.message = envelope.Status.Message
+	msg.responseStatus.attributes, err = readGraphSONStringMap(envelope.Status.Attributes)
+	if err != nil {
+		return msg, err
+	}
+	msg.responseResult.meta, err = readGraphSONStringMap(envelope.Result.Meta)
+	if err != nil {
+		return msg, err
+	}
+	msg.responseResult.data, err = readGraphSON(envelope.Result.Data)
+	if err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+func typedGraphSON(typeName string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{graphSONTypeKey: typeName, graphSONValueKey: value}
+}
+
+// graphSONEnumTypes are the GraphSON types of the enums.
+var graphSONEnumTypes = map[reflect.Type]string{
+	reflect.TypeOf(barrier("")):     "g:Barrier",
+	reflect.TypeOf(cardinality("")): "g:Cardinality",
+	reflect.TypeOf(column("")):      "g:Column",
+	reflect.TypeOf(direction("")):   "g:Direction",
+	reflect.TypeOf(merge("")):       "g:Merge",
+	reflect.TypeOf(operator("")):    "g:Operator",
+	reflect.TypeOf(order("")):       "g:Order",
+	reflect.TypeOf(pick("")):        "g:Pick",
+	reflect.TypeOf(pop("")):         "g:Pop",
+	reflect.TypeOf(scope("")):       "g:Scope",
+	reflect.TypeOf(t("")):           "g:T",
+}
+
+// write converts a value to its GraphSON representation, which is then marshalled with encoding/json.
+func (serializer *graphSONTypeSerializer) write(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string, bool:
+		return v, nil
+	case *Bytecode:
+		return serializer.writeBytecode(v)
+	case Bytecode:
+		return serializer.writeBytecode(&v)
+	case *GraphTraversal:
+		return serializer.writeBytecode(v.Bytecode)
+	case int64:
+		return typedGraphSON("g:Int64", v), nil
+	case int:
+		return typedGraphSON("g:Int64", int64(v)), nil
+	case uint32:
+		return typedGraphSON("g:Int64", int64(v)), nil
+	case int32:
+		return typedGraphSON("g:Int32", v), nil
+	case uint16:
+		return typedGraphSON("g:Int32", int32(v)), nil
+	case int16:
+		return typedGraphSON("gx:Int16", v), nil
+	case int8:
+		return typedGraphSON("gx:Int16", int16(v)), nil
+	case uint8:
+		return typedGraphSON("gx:Byte", int8(v)), nil
+	case uint:
+		return typedGraphSON("gx:BigInteger", json.Number(strconv.FormatUint(uint64(v), 10))), nil
+	case uint64:
+		return typedGraphSON("gx:BigInteger", json.Number(strconv.FormatUint(v, 10))), nil
+	case *big.Int:
+		return typedGraphSON("gx:BigInteger", json.Number(v.String())), nil
+	case float32:
+		return typedGraphSON("g:Float", writeGraphSONFloat(float64(v), 32)), nil
+	case float64:
+		return typedGraphSON("g:Double", writeGraphSONFloat(v, 64)), nil
+	case *BigDecimal:
+		return typedGraphSON("gx:BigDecimal", json.Number(formatBigDecimal(v))), nil
+	case BigDecimal:
+		return typedGraphSON("gx:BigDecimal", json.Number(formatBigDecimal(&v))), nil
+	case uuid.UUID:
+		return typedGraphSON("g:UUID", v.String()), nil
+	case time.Time:
+		return typedGraphSON("g:Date", v.UnixMilli()), nil
+	case time.Duration:
+		return typedGraphSON("gx:Duration", formatISODuration(v)), nil
+	case *ByteBuffer:
+		return typedGraphSON("gx:ByteBuffer", base64.StdEncoding.EncodeToString(v.Data)), nil
+	case ByteBuffer:
+		return typedGraphSON("gx:ByteBuffer", base64.StdEncoding.EncodeToString(v.Data)), nil
+	case *GremlinType:
+		return typedGraphSON("g:Class", v.Fqcn), nil
+	case GremlinType:
+		return typedGraphSON("g:Class", v.Fqcn), nil
+	case *Vertex:
+		return serializer.writeFields("g:Vertex", "id", v.Id, "label", v.Label)
+	case *Edge:
+		return serializer.writeFields("g:Edge", "id", v.Id, "label", v.Label, "inV", v.InV.Id,
+			"inVLabel", v.InV.Label, "outV", v.OutV.Id, "outVLabel", v.OutV.Label)
+	case *VertexProperty:
+		return serializer.writeFields("g:VertexProperty", "id", v.Id, "label", v.Label, "value", v.Value)
+	case *Property:
+		return serializer.writeFields("g:Property", "key", v.Key, "value", v.Value)
+	case *Path:
+		return serializer.writeFields("g:Path", "labels", v.Labels, "objects", v.Objects)
+	case *Tree:
+		return serializer.writeTree(v)
+	case *BulkSet:
+		elements := make([]interface{}, 0, 2*len(v.values))
+		for i, value := range v.values {
+			element, err := serializer.write(value)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element, typedGraphSON("g:Int64", v.bulks[i]))
+		}
+		return typedGraphSON("g:BulkSet", elements), nil
+	case *Traverser:
+		return serializer.writeFields("g:Traverser", "bulk", v.bulk, "value", v.value)
+	case Tree:
+		return serializer.writeTree(&v)
+	case Set:
+		list, err := serializer.writeList(v.ToSlice())
+		if err != nil {
+			return nil, err
+		}
+		return typedGraphSON("g:Set", list), nil
+	case *Lambda:
+		language := v.Language
+		if language == "" {
+			language = "gremlin-groovy"
+		}
+		return typedGraphSON("g:Lambda", map[string]interface{}{"script": v.Script, "language": language, "arguments": -1}), nil
+	case *traversalStrategy:
+		configuration, err := serializer.write(v.configuration)
+		if err != nil {
+			return nil, err
+		}
+		// Strategies are identified by their simple class name.
+		return typedGraphSON("g:"+v.name[strings.LastIndex(v.name, ".")+1:], configuration), nil
+	case *p:
+		return serializer.writeP("g:P", v.operator, v.values)
+	case p:
+		return serializer.writeP("g:P", v.operator, v.values)
+	case *textP:
+		return serializer.writeP("g:TextP", v.operator, v.values)
+	case textP:
+		return serializer.writeP("g:TextP", v.operator, v.values)
+	case *Binding:
+		return serializer.writeFields("g:Binding", "key", v.Key, "value", v.Value)
+	case Binding:
+		return serializer.writeFields("g:Binding", "key", v.Key, "value", v.Value)
+	}
+
+	if typeName, ok := graphSONEnumTypes[reflect.TypeOf(value)]; ok {
+		return typedGraphSON(typeName, reflect.ValueOf(value).String()), nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Map:
+		return serializer.writeMap(value)
+	case reflect.Array, reflect.Slice:
+		list, err := serializer.writeList(value)
+		if err != nil {
+			return nil, err
+		}
+		return typedGraphSON("g:List", list), nil
+	default:
+		serializer.logHandler.logf(Error, serializeDataTypeError, reflect.TypeOf(value).Name())
+		return nil, newError(E1201GraphSONWriteUnknownTypeError, reflect.TypeOf(value).Name())
+	}
+}
+
+// writeTree writes a g:Tree whose @value is a list of objects with the key and the subtree of each child.
+func (serializer *graphSONTypeSerializer) writeTree(tree *Tree) (interface{}, error) {
+	children := make([]interface{}, 0, len(tree.Children))
+	for _, child := range tree.Children {
+		key, err := serializer.write(child.Key)
+		if err != nil {
+			return nil, err
+		}
+		subtree, err := serializer.writeTree(&child.Tree)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, map[string]interface{}{"key": key, "value": subtree})
+	}
+	return typedGraphSON("g:Tree", children), nil
+}
+
+// writeFields writes a typed value whose @value is an object with the given pairs of field names and values.
+func (serializer *graphSONTypeSerializer) writeFields(typeName string, fields ...interface{}) (interface{}, error) {
+	object := make(map[string]interface{}, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		value, err := serializer.write(fields[i+1])
+		if err != nil {
+			return nil, err
+		}
+		object[fields[i].(string)] = value
+	}
+	return typedGraphSON(typeName, object), nil
+}
+
+func (serializer *graphSONTypeSerializer) writeList(value interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(value)
+	list := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		element, err := serializer.write(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, element)
+	}
+	return list, nil
+}
+
+// writeMap writes a g:Map, whose @value lists the keys and values alternately.
+func (serializer *graphSONTypeSerializer) writeMap(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	list := make([]interface{}, 0, 2*v.Len())
+	iterator := v.MapRange()
+	for iterator.Next() {
+		key, err := serializer.write(iterator.Key().Interface())
+		if err != nil {
+			return nil, err
+		}
+		element, err := serializer.write(iterator.Value().Interface())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, key, element)
+	}
+	return typedGraphSON("g:Map", list), nil
+}
+
+// writeP writes a predicate. Predicates with a single value have it as their value, all others have a list of values.
+func (serializer *graphSONTypeSerializer) writeP(typeName string, operator string, values []interface{}) (interface{}, error) {
+	var value interface{}
+	var err error
+	if len(values) == 1 && (operator != "within" && operator != "without" || reflect.TypeOf(values[0]) != nil &&
+		reflect.TypeOf(values[0]).Kind() == reflect.Slice) {
+		value, err = serializer.write(values[0])
+	} else {
+		value, err = serializer.write(values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return typedGraphSON(typeName, map[string]interface{}{"predicate": operator, "value": value}), nil
+}
+
+// writeBytecode writes the instructions of the bytecode as lists of the operator followed by the arguments.
+func (serializer *graphSONTypeSerializer) writeBytecode(bytecode *Bytecode) (interface{}, error) {
+	if bytecode == nil {
+		return nil, newError(E0402BytecodeWriterError)
+	}
+	value := map[string]interface{}{}
+	for key, instructions := range map[string][]instruction{"step": bytecode.stepInstructions, "source": bytecode.sourceInstructions} {
+		if len(instructions) == 0 {
+			continue
+		}
+		list := make([]interface{}, 0, len(instructions))
+		for _, insn := range instructions {
+			written := []interface{}{insn.operator}
+			for _, argument := range insn.arguments {
+				argumentValue, err := serializer.write(argument)
+				if err != nil {
+					return nil, err
+				}
+				written = append(written, argumentValue)
+			}
+			list = append(list, written)
+		}
+		value[key] = list
+	}
+	return typedGraphSON("g:Bytecode", value), nil
+}
+
+// writeGraphSONFloat writes the special values of floating point numbers as strings, as they are not valid in JSON.
+func writeGraphSONFloat(value float64, bitSize int) interface{} {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	}
+	return json.Number(strconv.FormatFloat(value, 'g', -1, bitSize))
+}
+
+// formatBigDecimal formats a BigDecimal as a decimal number, using an exponent if its scale is negative.
+func formatBigDecimal(value *BigDecimal) string {
+	unscaled := value.UnscaledValue.String()
+	sign := ""
+	if strings.HasPrefix(unscaled, "-") {
+		sign, unscaled = "-", unscaled[1:]
+	}
+	scale := int(value.Scale)
+	switch {
+	case scale == 0:
+		return sign + unscaled
+	case scale < 0:
+		return sign + unscaled + "E+" + strconv.Itoa(-scale)
+	}
+	if len(unscaled) <= scale {
+		unscaled = strings.Repeat("0", scale-len(unscaled)+1) + unscaled
+	}
+	return sign + unscaled[:len(unscaled)-scale] + "." + unscaled[len(unscaled)-scale:]
+}
+
+// parseBigDecimal parses a decimal number, which may have an exponent, into a BigDecimal.
+func parseBigDecimal(value string) (*BigDecimal, error) {
+	mantissa, exponent := value, 0
+	if index := strings.IndexAny(value, "eE"); index >= 0 {
+		var err error
+		mantissa = value[:index]
+		exponent, err = strconv.Atoi(strings.TrimPrefix(value[index+1:], "+"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	scale := 0
+	if index := strings.Index(mantissa, "."); index >= 0 {
+		scale = len(mantissa) - index - 1
+		mantissa = mantissa[:index] + mantissa[index+1:]
+	}
+	bigDecimal := &BigDecimal{Scale: int32(scale - exponent)}
+	if _, ok := bigDecimal.UnscaledValue.SetString(mantissa, 10); !ok {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "gx:BigDecimal", value)
+	}
+	return bigDecimal, nil
+}
+
+// formatISODuration formats a duration in the ISO-8601 format used by java.time.Duration, such as PT90.5S.
+func formatISODuration(duration time.Duration) string {
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+	seconds, nanos := int64(duration/time.Second), int64(duration%time.Second)
+	if nanos == 0 {
+		return fmt.Sprintf("PT%s%dS", sign, seconds)
+	}
+	return fmt.Sprintf("PT%s%d.%sS", sign, seconds, strings.TrimRight(fmt.Sprintf("%09d", nanos), "0"))
+}
+
+var isoDurationPattern = regexp.MustCompile(`^([-+]?)P(?:([-+]?[0-9]+)D)?(?:T(?:([-+]?[0-9]+)H)?(?:([-+]?[0-9]+)M)?(?:([-+]?)([0-9]+)(?:[.,]([0-9]{0,9}))?S)?)?$`)
+
+// parseISODuration parses a duration in the ISO-8601 format used by java.time.Duration.
+func parseISODuration(value string) (time.Duration, error) {
+	groups := isoDurationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if groups == nil {
+		return 0, newError(E1203GraphSONReadInvalidValueError, "gx:Duration", value)
+	}
+	var duration time.Duration
+	for i, unit := range map[int]time.Duration{2: 24 * time.Hour, 3: time.Hour, 4: time.Minute} {
+		if groups[i] != "" {
+			amount, err := strconv.ParseInt(groups[i], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			duration += time.Duration(amount) * unit
+		}
+	}
+	if groups[6] != "" {
+		seconds, err := strconv.ParseInt(groups[6], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		fraction := time.Duration(seconds) * time.Second
+		if groups[7] != "" {
+			nanos, err := strconv.ParseInt((groups[7] + "000000000")[:9], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			fraction += time.Duration(nanos)
+		}
+		if groups[5] == "-" {
+			fraction = -fraction
+		}
+		duration += fraction
+	}
+	if groups[1] == "-" {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+func initGraphSONReaders() {
+	graphSONReaders = map[string]graphSONReader{
+		// Primitive
+		"g:Int32": func(value interface{}) (interface{}, error) {
+			n, err := readGraphSONInt(value, "g:Int32", 32)
+			return int32(n), err
+		},
+		"g:Int64": func(value interface{}) (interface{}, error) {
+			return readGraphSONInt(value, "g:Int64", 64)
+		},
+		"gx:Int16": func(value interface{}) (interface{}, error) {
+			n, err := readGraphSONInt(value, "gx:Int16", 16)
+			return int16(n), err
+		},
+		"gx:Byte": func(value interface{}) (interface{}, error) {
+			n, err := readGraphSONInt(value, "gx:Byte", 8)
+			return uint8(int8(n)), err
+		},
+		"g:Float": func(value interface{}) (interface{}, error) {
+			f, err := readGraphSONFloat(value, "g:Float", 32)
+			return float32(f), err
+		},
+		"g:Double": func(value interface{}) (interface{}, error) {
+			return readGraphSONFloat(value, "g:Double", 64)
+		},
+		"gx:BigInteger": func(value interface{}) (interface{}, error) {
+			n, ok := new(big.Int).SetString(fmt.Sprint(value), 10)
+			if !ok {
+				return nil, newError(E1203GraphSONReadInvalidValueError, "gx:BigInteger", value)
+			}
+			return n, nil
+		},
+		"gx:BigDecimal": func(value interface{}) (interface{}, error) {
+			return parseBigDecimal(fmt.Sprint(value))
+		},
+		"gx:Char": func(value interface{}) (interface{}, error) {
+			return readGraphSONString(value, "gx:Char")
+		},
+
+		// Composite
+		"g:List": readGraphSONList,
+		"g:Set": func(value interface{}) (interface{}, error) {
+			list, err := readGraphSONList(value)
+			if err != nil {
+				return nil, err
+			}
+			return NewSimpleSet(list.([]interface{})...), nil
+		},
+		"g:Map": readGraphSONMap,
+		"g:UUID": func(value interface{}) (interface{}, error) {
+			s, err := readGraphSONString(value, "g:UUID")
+			if err != nil {
+				return nil, err
+			}
+			return uuid.Parse(s)
+		},
+		"g:Class": func(value interface{}) (interface{}, error) {
+			s, err := readGraphSONString(value, "g:Class")
+			return &GremlinType{Fqcn: s}, err
+		},
+		"gx:ByteBuffer": func(value interface{}) (interface{}, error) {
+			s, err := readGraphSONString(value, "gx:ByteBuffer")
+			if err != nil {
+				return nil, err
+			}
+			data, err := base64.StdEncoding.DecodeString(s)
+			return &ByteBuffer{Data: data}, err
+		},
+
+		// Date Time
+		"g:Date":      readGraphSONTime,
+		"g:Timestamp": readGraphSONTime,
+		"gx:Duration": func(value interface{}) (interface{}, error) {
+			s, err := readGraphSONString(value, "gx:Duration")
+			if err != nil {
+				return nil, err
+			}
+			return parseISODuration(s)
+		},
+
+		// Graph
+		"g:Vertex":         readGraphSONVertex,
+		"g:Edge":           readGraphSONEdge,
+		"g:VertexProperty": readGraphSONVertexProperty,
+		"g:Property":       readGraphSONProperty,
+		"g:Path":           readGraphSONPath,
+		"g:Traverser":      readGraphSONTraverser,
+		"g:BulkSet":        readGraphSONBulkSet,
+		"g:Tree":           readGraphSONTree,
+		"tinker:graph":     readGraphSONGraph,
+		"g:Binding":        readGraphSONBinding,
+
+		// Process
+		"g:Bytecode":         readGraphSONBytecode,
+		"g:P":                readGraphSONP,
+		"g:TextP":            readGraphSONTextP,
+		"g:Lambda":           readGraphSONLambda,
+		"g:Metrics":          readGraphSONMetrics,
+		"g:TraversalMetrics": readGraphSONTraversalMetrics,
+	}
+	for _, typeName := range graphSONEnumTypes {
+		typeName := typeName
+		graphSONReaders[typeName] = func(value interface{}) (interface{}, error) {
+			return readGraphSONString(value, typeName)
+		}
+	}
+}
+
+// readGraphSON converts a value decoded by encoding/json from its GraphSON representation.
+func readGraphSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if typeName, ok := v[graphSONTypeKey].(string); ok {
+			reader, ok := graphSONReaders[typeName]
+			if !ok {
+				return nil, newError(E1202GraphSONReadUnknownTypeError, typeName)
+			}
+			return reader(v[graphSONValueKey])
+		}
+		object := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			converted, err := readGraphSON(element)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = converted
+		}
+		return object, nil
+	case []interface{}:
+		return readGraphSONList(v)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		return v.Float64()
+	default:
+		return v, nil
+	}
+}
+
+// readGraphSONObject returns the fields of an untyped JSON object, as used as @value by most types.
+func readGraphSONObject(value interface{}, typeName string) (map[string]interface{}, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, newError(E1203GraphSONReadInvalidValueError, typeName, value)
+	}
+	return object, nil
+}
+
+func readGraphSONString(value interface{}, typeName string) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", newError(E1203GraphSONReadInvalidValueError, typeName, value)
+	}
+	return s, nil
+}
+
+func readGraphSONInt(value interface{}, typeName string, bitSize int) (int64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, newError(E1203GraphSONReadInvalidValueError, typeName, value)
+	}
+	n, err := strconv.ParseInt(number.String(), 10, bitSize)
+	if err != nil {
+		return 0, newError(E1203GraphSONReadInvalidValueError, typeName, value)
+	}
+	return n, nil
+}
+
+func readGraphSONFloat(value interface{}, typeName string, bitSize int) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseFloat(v.String(), bitSize)
+	case string:
+		switch v {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+	}
+	return 0, newError(E1203GraphSONReadInvalidValueError, typeName, value)
+}
+
+func readGraphSONTime(value interface{}) (interface{}, error) {
+	ms, err := readGraphSONInt(value, "g:Date", 64)
+	if err != nil {
+		return nil, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func readGraphSONList(value interface{}) (interface{}, error) {
+	if value == nil {
+		return []interface{}{}, nil
+	}
+	elements, ok := value.([]interface{})
+	if !ok {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:List", value)
+	}
+	list := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		converted, err := readGraphSON(element)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, converted)
+	}
+	return list, nil
+}
+
+// readGraphSONMap reads a g:Map, converting keys which cannot be map keys in Go in the same way as readMap.
+func readGraphSONMap(value interface{}) (interface{}, error) {
+	list, err := readGraphSONList(value)
+	if err != nil {
+		return nil, err
+	}
+	elements := list.([]interface{})
+	if len(elements)%2 != 0 {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:Map", value)
+	}
+	mapData := make(map[interface{}]interface{}, len(elements)/2)
+	for i := 0; i < len(elements); i += 2 {
+		k, v := elements[i], elements[i+1]
+		if k == nil {
+			mapData[nil] = v
+			continue
+		}
+		switch reflect.TypeOf(k).Kind() {
+		case reflect.Map:
+			mapData[&k] = v
+		case reflect.Slice:
+			mapData[fmt.Sprint(k)] = v
+		default:
+			mapData[k] = v
+		}
+	}
+	return mapData, nil
+}
+
+// readGraphSONStringMap reads the status attributes and result meta, which are maps with string keys.
+func readGraphSONStringMap(value interface{}) (map[string]interface{}, error) {
+	converted, err := readGraphSON(value)
+	if err != nil {
+		return nil, err
+	}
+	switch m := converted.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return m, nil
+	case map[interface{}]interface{}:
+		stringMap := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			stringMap[fmt.Sprint(k)] = v
+		}
+		return stringMap, nil
+	default:
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:Map", value)
+	}
+}
+
+// readGraphSONFields reads the named fields of the @value of a type, where absent fields are nil.
+func readGraphSONFields(value interface{}, typeName string, names ...string) ([]interface{}, error) {
+	object, err := readGraphSONObject(value, typeName)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]interface{}, len(names))
+	for i, name := range names {
+		fields[i], err = readGraphSON(object[name])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func stringField(field interface{}) string {
+	s, _ := field.(string)
+	return s
+}
+
+// readGraphSONElementProperties reads the properties of an element, which are keyed by their name. They are returned
+// as a list ordered by name, as read by GraphBinary.
+func readGraphSONElementProperties(value interface{}, typeName string) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	object, err := readGraphSONObject(value, typeName)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	properties := make([]interface{}, 0, len(object))
+	for _, key := range keys {
+		property, err := readGraphSON(object[key])
+		if err != nil {
+			return nil, err
+		}
+		switch p := property.(type) {
+		case []interface{}:
+			// Vertices have a list of vertex properties for each key.
+			properties = append(properties, p...)
+		case *Property, *VertexProperty:
+			properties = append(properties, p)
+		default:
+			// Vertex properties have the values of their meta-properties.
+			properties = append(properties, &Property{Key: key, Value: p})
+		}
+	}
+	return properties, nil
+}
+
+func readGraphSONVertex(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Vertex", "id", "label")
+	if err != nil {
+		return nil, err
+	}
+	v := new(Vertex)
+	v.Id, v.Label = fields[0], stringField(fields[1])
+	v.Properties, err = readGraphSONElementProperties(value.(map[string]interface{})["properties"], "g:Vertex")
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func readGraphSONEdge(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Edge", "id", "label", "inV", "inVLabel", "outV", "outVLabel")
+	if err != nil {
+		return nil, err
+	}
+	e := new(Edge)
+	e.Id, e.Label = fields[0], stringField(fields[1])
+	e.InV = Vertex{Element{Id: fields[2], Label: stringField(fields[3])}}
+	e.OutV = Vertex{Element{Id: fields[4], Label: stringField(fields[5])}}
+	e.Properties, err = readGraphSONElementProperties(value.(map[string]interface{})["properties"], "g:Edge")
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func readGraphSONVertexProperty(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:VertexProperty", "id", "label", "value")
+	if err != nil {
+		return nil, err
+	}
+	vp := new(VertexProperty)
+	vp.Id, vp.Label, vp.Value = fields[0], stringField(fields[1]), fields[2]
+	vp.Key = vp.Label
+	vp.Properties, err = readGraphSONElementProperties(value.(map[string]interface{})["properties"], "g:VertexProperty")
+	if err != nil {
+		return nil, err
+	}
+	return vp, nil
+}
+
+func readGraphSONProperty(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Property", "key", "value")
+	if err != nil {
+		return nil, err
+	}
+	return &Property{Key: stringField(fields[0]), Value: fields[1]}, nil
+}
+
+func readGraphSONPath(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Path", "labels", "objects")
+	if err != nil {
+		return nil, err
+	}
+	path := new(Path)
+	labels, _ := fields[0].([]interface{})
+	for _, label := range labels {
+		set, ok := label.(*SimpleSet)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "g:Path", value)
+		}
+		path.Labels = append(path.Labels, set)
+	}
+	path.Objects, _ = fields[1].([]interface{})
+	return path, nil
+}
+
+func readGraphSONTraverser(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Traverser", "bulk", "value")
+	if err != nil {
+		return nil, err
+	}
+	bulk, ok := fields[0].(int64)
+	if !ok {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:Traverser", value)
+	}
+	return &Traverser{bulk: bulk, value: fields[1]}, nil
+}
+
+// readGraphSONBulkSet reads a g:BulkSet, whose @value lists each value followed by its bulk.
+func readGraphSONBulkSet(value interface{}) (interface{}, error) {
+	list, err := readGraphSONList(value)
+	if err != nil {
+		return nil, err
+	}
+	elements := list.([]interface{})
+	if len(elements)%2 != 0 {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:BulkSet", value)
+	}
+	bulkSet := NewBulkSet()
+	for i := 0; i < len(elements); i += 2 {
+		bulk, ok := elements[i+1].(int64)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "g:BulkSet", value)
+		}
+		bulkSet.add(elements[i], bulk)
+	}
+	return bulkSet, nil
+}
+
+func readGraphSONTree(value interface{}) (interface{}, error) {
+	children, ok := value.([]interface{})
+	if !ok && value != nil {
+		return nil, newError(E1203GraphSONReadInvalidValueError, "g:Tree", value)
+	}
+	tree := new(Tree)
+	for _, child := range children {
+		fields, err := readGraphSONFields(child, "g:Tree", "key", "value")
+		if err != nil {
+			return nil, err
+		}
+		subtree, ok := fields[1].(*Tree)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "g:Tree", value)
+		}
+		tree.Children = append(tree.Children, &TreeNode{Key: fields[0], Tree: *subtree})
+	}
+	return tree, nil
+}
+
+func readGraphSONGraph(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "tinker:graph", "vertices", "edges")
+	if err != nil {
+		return nil, err
+	}
+	g := NewGraph()
+	vertices, _ := fields[0].([]interface{})
+	for _, vertex := range vertices {
+		v, ok := vertex.(*Vertex)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "tinker:graph", value)
+		}
+		g.AddVertex(v)
+	}
+	edges, _ := fields[1].([]interface{})
+	for _, edge := range edges {
+		e, ok := edge.(*Edge)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "tinker:graph", value)
+		}
+		g.AddEdge(e)
+	}
+	return g, nil
+}
+
+func readGraphSONBinding(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Binding", "key", "value")
+	if err != nil {
+		return nil, err
+	}
+	return &Binding{Key: stringField(fields[0]), Value: fields[1]}, nil
+}
+
+func readGraphSONBytecode(value interface{}) (interface{}, error) {
+	object, err := readGraphSONObject(value, "g:Bytecode")
+	if err != nil {
+		return nil, err
+	}
+	bc := NewBytecode(nil)
+	bc.stepInstructions, err = readGraphSONInstructions(object["step"])
+	if err != nil {
+		return nil, err
+	}
+	bc.sourceInstructions, err = readGraphSONInstructions(object["source"])
+	if err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+func readGraphSONInstructions(value interface{}) ([]instruction, error) {
+	list, _ := value.([]interface{})
+	instructions := make([]instruction, 0, len(list))
+	for _, element := range list {
+		insn, ok := element.([]interface{})
+		if !ok || len(insn) == 0 {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "g:Bytecode", value)
+		}
+		operator, ok := insn[0].(string)
+		if !ok {
+			return nil, newError(E1203GraphSONReadInvalidValueError, "g:Bytecode", value)
+		}
+		arguments, err := readGraphSONList(insn[1:])
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, instruction{operator: operator, arguments: arguments.([]interface{})})
+	}
+	return instructions, nil
+}
+
+func readGraphSONP(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:P", "predicate", "value")
+	if err != nil {
+		return nil, err
+	}
+	values, ok := fields[1].([]interface{})
+	if !ok {
+		values = []interface{}{fields[1]}
+	}
+	return &p{operator: stringField(fields[0]), values: values}, nil
+}
+
+func readGraphSONTextP(value interface{}) (interface{}, error) {
+	predicate, err := readGraphSONP(value)
+	if err != nil {
+		return nil, err
+	}
+	return (*textP)(predicate.(*p)), nil
+}
+
+func readGraphSONLambda(value interface{}) (interface{}, error) {
+	fields, err := readGraphSONFields(value, "g:Lambda", "script", "language")
+	if err != nil {
+		return nil, err
+	}
+	return &Lambda{Script: stringField(fields[0]), Language: stringField(fields[1])}, nil
+}
+
+// readGraphSONMetrics reads g:Metrics, whose @value is a g:Map with the duration in milliseconds.
+func readGraphSONMetrics(value interface{}) (interface{}, error) {
+	m, err := readGraphSONStringMap(value)
+	if err != nil {
+		return nil, err
+	}
+	metrics := new(Metrics)
+	metrics.Id, _ = m["id"].(string)
+	metrics.Name, _ = m["name"].(string)
+	metrics.Duration = graphSONMillisToNanos(m["dur"])
+	counts, _ := m["counts"].(map[interface{}]interface{})
+	metrics.Counts = make(map[string]int64, len(counts))
+	for k, v := range counts {
+		metrics.Counts[fmt.Sprint(k)], _ = v.(int64)
+	}
+	annotations, _ := m["annotations"].(map[interface{}]interface{})
+	metrics.Annotations = make(map[string]interface{}, len(annotations))
+	for k, v := range annotations {
+		metrics.Annotations[fmt.Sprint(k)] = v
+	}
+	nested, _ := m["metrics"].([]interface{})
+	metrics.NestedMetrics = make([]Metrics, 0, len(nested))
+	for _, n := range nested {
+		if nestedMetrics, ok := n.(*Metrics); ok {
+			metrics.NestedMetrics = append(metrics.NestedMetrics, *nestedMetrics)
+		}
+	}
+	return metrics, nil
+}
+
+func readGraphSONTraversalMetrics(value interface{}) (interface{}, error) {
+	m, err := readGraphSONStringMap(value)
+	if err != nil {
+		return nil, err
+	}
+	traversalMetrics := new(TraversalMetrics)
+	traversalMetrics.Duration = graphSONMillisToNanos(m["dur"])
+	nested, _ := m["metrics"].([]interface{})
+	traversalMetrics.Metrics = make([]Metrics, 0, len(nested))
+	for _, n := range nested {
+		if metrics, ok := n.(*Metrics); ok {
+			traversalMetrics.Metrics = append(traversalMetrics.Metrics, *metrics)
+		}
+	}
+	return traversalMetrics, nil
+}
+
+func graphSONMillisToNanos(value interface{}) int64 {
+	switch ms := value.(type) {
+	case float64:
+		return int64(ms * float64(time.Millisecond))
+	case int64:
+		return ms * int64(time.Millisecond)
+	}
+	return 0
+}
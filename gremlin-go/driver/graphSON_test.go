@@ -0,0 +1,353 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func newTestGraphSONSerializer() graphSONSerializer {
+	return newGraphSONSerializer(newLogHandler(&defaultLogger{}, Error, language.English)).(graphSONSerializer)
+}
+
+// graphSONRoundTrip writes the value to JSON and reads it back.
+func graphSONRoundTrip(t *testing.T, value interface{}) interface{} {
+	written, err := newTestGraphSONSerializer().ser.write(value)
+	assert.Nil(t, err)
+	data, err := json.Marshal(written)
+	assert.Nil(t, err)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded interface{}
+	assert.Nil(t, decoder.Decode(&decoded))
+	read, err := readGraphSON(decoded)
+	assert.Nil(t, err)
+	return read
+}
+
+// graphSONJSON returns the JSON written for the value.
+func graphSONJSON(t *testing.T, value interface{}) string {
+	written, err := newTestGraphSONSerializer().ser.write(value)
+	assert.Nil(t, err)
+	data, err := json.Marshal(written)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestGraphSONSerializer(t *testing.T) {
+	t.Run("Test serializeMessage", func(t *testing.T) {
+		g := NewDefaultGraphTraversalSource()
+		bytecode := g.WithStrategies(ReadOnlyStrategy()).V().Has("age", P.Gt(int32(30))).
+			Has("name", TextP.Containing("ark")).Order().By("age", Order.Desc).Bytecode
+		request := makeBytecodeRequest(bytecode, "g", "")
+		message, err := newTestGraphSONSerializer().serializeMessage(&request)
+		assert.Nil(t, err)
+		assert.Equal(t, byte(len(graphSONV3MimeType)), message[0])
+		assert.Equal(t, graphSONV3MimeType, string(message[1:len(graphSONV3MimeType)+1]))
+
+		var envelope map[string]interface{}
+		assert.Nil(t, json.Unmarshal(message[len(graphSONV3MimeType)+1:], &envelope))
+		assert.Equal(t, map[string]interface{}{"@type": "g:UUID", "@value": request.requestID.String()}, envelope["requestId"])
+		assert.Equal(t, bytecodeOp, envelope["op"])
+		assert.Equal(t, bytecodeProcessor, envelope["processor"])
+
+		gremlin, err := json.Marshal(envelope["args"].(map[string]interface{})["gremlin"])
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"@type":"g:Bytecode","@value":{
+			"source":[["withStrategies",{"@type":"g:ReadOnlyStrategy","@value":{"@type":"g:Map","@value":[]}}]],
+			"step":[["V"],
+				["has","age",{"@type":"g:P","@value":{"predicate":"gt","value":{"@type":"g:Int32","@value":30}}}],
+				["has","name",{"@type":"g:TextP","@value":{"predicate":"containing","value":"ark"}}],
+				["order"],
+				["by","age",{"@type":"g:Order","@value":"desc"}]]}}`, string(gremlin))
+	})
+
+	t.Run("Test write predicates", func(t *testing.T) {
+		assert.JSONEq(t, `{"@type":"g:P","@value":{"predicate":"within","value":{"@type":"g:List","@value":["a","b"]}}}`,
+			graphSONJSON(t, P.Within("a", "b")))
+		assert.JSONEq(t, `{"@type":"g:P","@value":{"predicate":"within","value":{"@type":"g:List","@value":["a"]}}}`,
+			graphSONJSON(t, P.Within("a")))
+		assert.JSONEq(t, `{"@type":"g:P","@value":{"predicate":"within","value":{"@type":"g:List","@value":["a","b"]}}}`,
+			graphSONJSON(t, P.Within([]interface{}{"a", "b"})))
+		assert.JSONEq(t, `{"@type":"g:P","@value":{"predicate":"between","value":{"@type":"g:List","@value":[
+			{"@type":"g:Int64","@value":1},{"@type":"g:Int64","@value":5}]}}}`, graphSONJSON(t, P.Between(1, 5)))
+		assert.JSONEq(t, `{"@type":"g:P","@value":{"predicate":"and","value":{"@type":"g:List","@value":[
+			{"@type":"g:P","@value":{"predicate":"gt","value":{"@type":"g:Int64","@value":1}}},
+			{"@type":"g:P","@value":{"predicate":"lt","value":{"@type":"g:Int64","@value":5}}}]}}}`,
+			graphSONJSON(t, P.Gt(1).And(P.Lt(5))))
+	})
+
+	t.Run("Test write lambda, binding and nested traversal", func(t *testing.T) {
+		assert.JSONEq(t, `{"@type":"g:Lambda","@value":{"script":"it.get()","language":"gremlin-groovy","arguments":-1}}`,
+			graphSONJSON(t, &Lambda{Script: "it.get()"}))
+		assert.JSONEq(t, `{"@type":"g:Binding","@value":{"key":"x","value":{"@type":"g:Int64","@value":1}}}`,
+			graphSONJSON(t, &Binding{Key: "x", Value: 1}))
+		assert.JSONEq(t, `{"@type":"g:Bytecode","@value":{"step":[["out","knows"]]}}`, graphSONJSON(t, T__.Out("knows")))
+	})
+
+	t.Run("Test write unknown type", func(t *testing.T) {
+		_, err := newTestGraphSONSerializer().ser.write(struct{}{})
+		assert.True(t, isSameErrorCode(err, newError(E1201GraphSONWriteUnknownTypeError)))
+	})
+
+	t.Run("Test round trip", func(t *testing.T) {
+		id := uuid.New()
+		now := time.UnixMilli(time.Now().UnixMilli())
+		bigInt, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		for _, testCase := range []struct {
+			value    interface{}
+			expected interface{}
+		}{
+			{nil, nil},
+			{"string", "string"},
+			{true, true},
+			{int32(-1), int32(-1)},
+			{int64(math.MaxInt64), int64(math.MaxInt64)},
+			{7, int64(7)},
+			{int16(5), int16(5)},
+			{int8(-3), int16(-3)},
+			{uint8(200), uint8(200)},
+			{float32(1.5), float32(1.5)},
+			{2.25, 2.25},
+			{math.Inf(-1), math.Inf(-1)},
+			{bigInt, bigInt},
+			{uint64(math.MaxUint64), new(big.Int).SetUint64(math.MaxUint64)},
+			{&BigDecimal{Scale: 3, UnscaledValue: *big.NewInt(-123456)}, &BigDecimal{Scale: 3, UnscaledValue: *big.NewInt(-123456)}},
+			{id, id},
+			{now, now},
+			{90*time.Second + 5*time.Millisecond, 90*time.Second + 5*time.Millisecond},
+			{&ByteBuffer{Data: []byte{1, 2, 3}}, &ByteBuffer{Data: []byte{1, 2, 3}}},
+			{&GremlinType{Fqcn: "java.lang.String"}, &GremlinType{Fqcn: "java.lang.String"}},
+			{[]interface{}{"a", int32(1)}, []interface{}{"a", int32(1)}},
+			{NewSimpleSet("a", "b"), NewSimpleSet("a", "b")},
+			{map[string]interface{}{"a": int32(1)}, map[interface{}]interface{}{"a": int32(1)}},
+			{T.Id, "id"},
+			{Direction.Out, "OUT"},
+			{Cardinality.List, "list"},
+			{&Vertex{Element{Id: int64(1), Label: "person"}}, &Vertex{Element{Id: int64(1), Label: "person"}}},
+			{&Edge{Element: Element{Id: int64(7), Label: "knows"}, InV: Vertex{Element{Id: int64(2), Label: "person"}},
+				OutV: Vertex{Element{Id: int64(1), Label: "person"}}},
+				&Edge{Element: Element{Id: int64(7), Label: "knows"}, InV: Vertex{Element{Id: int64(2), Label: "person"}},
+					OutV: Vertex{Element{Id: int64(1), Label: "person"}}}},
+			{&VertexProperty{Element: Element{Id: int64(0), Label: "name"}, Value: "marko"},
+				&VertexProperty{Element: Element{Id: int64(0), Label: "name"}, Key: "name", Value: "marko"}},
+			{&Property{Key: "weight", Value: 0.5}, &Property{Key: "weight", Value: 0.5}},
+			{&Path{Labels: []Set{NewSimpleSet("a")}, Objects: []interface{}{"x"}},
+				&Path{Labels: []Set{NewSimpleSet("a")}, Objects: []interface{}{"x"}}},
+			{&Binding{Key: "x", Value: "y"}, &Binding{Key: "x", Value: "y"}},
+			{P.Within("a", "b"), &p{operator: "within", values: []interface{}{"a", "b"}}},
+			{TextP.StartingWith("m"), &textP{operator: "startingWith", values: []interface{}{"m"}}},
+			{&Lambda{Script: "x", Language: "gremlin-lang"}, &Lambda{Script: "x", Language: "gremlin-lang"}},
+		} {
+			assert.Equal(t, testCase.expected, graphSONRoundTrip(t, testCase.value))
+		}
+		assert.True(t, math.IsNaN(graphSONRoundTrip(t, math.NaN()).(float64)))
+
+		assert.Equal(t, newTestTree(), graphSONRoundTrip(t, newTestTree()))
+		assert.Equal(t, &Tree{}, graphSONRoundTrip(t, Tree{}))
+
+		bytecode := NewDefaultGraphTraversalSource().V().Has("name", "marko").Out("knows").Bytecode
+		read := graphSONRoundTrip(t, bytecode).(*Bytecode)
+		assert.Equal(t, bytecode.stepInstructions, read.stepInstructions)
+	})
+
+	t.Run("Test deserializeMessage", func(t *testing.T) {
+		message := `{"requestId":"41d2e28a-20a4-4ab0-b379-d810dede3786","status":{"message":"","code":200,
+			"attributes":{"@type":"g:Map","@value":["host","/127.0.0.1:62742"]}},
+			"result":{"data":{"@type":"g:List","@value":[
+				{"@type":"g:Traverser","@value":{"bulk":{"@type":"g:Int64","@value":2},"value":
+					{"@type":"g:Vertex","@value":{"id":{"@type":"g:Int32","@value":1},"label":"person","properties":{
+						"name":[{"@type":"g:VertexProperty","@value":{"id":{"@type":"g:Int64","@value":0},"value":"marko","label":"name"}}],
+						"age":[{"@type":"g:VertexProperty","@value":{"id":{"@type":"g:Int64","@value":1},"value":{"@type":"g:Int32","@value":29},"label":"age",
+							"properties":{"since":{"@type":"g:Int32","@value":2010}}}}]}}}}},
+				{"@type":"g:Traverser","@value":{"bulk":{"@type":"g:Int64","@value":1},"value":
+					{"@type":"g:Edge","@value":{"id":{"@type":"g:Int32","@value":7},"label":"knows","inVLabel":"person","outVLabel":"person",
+						"inV":{"@type":"g:Int32","@value":2},"outV":{"@type":"g:Int32","@value":1},
+						"properties":{"weight":{"@type":"g:Property","@value":{"key":"weight","value":{"@type":"g:Double","@value":0.5}}}}}}}},
+				{"@type":"g:BulkSet","@value":["a",{"@type":"g:Int64","@value":2},"b",{"@type":"g:Int64","@value":1}]},
+				{"@type":"gx:BigDecimal","@value":123456789012345678901234567890.12},
+				{"@type":"g:Path","@value":{"labels":{"@type":"g:List","@value":[{"@type":"g:Set","@value":["a"]}]},
+					"objects":{"@type":"g:List","@value":["marko"]}}},
+				{"@type":"g:Map","@value":[{"@type":"g:T","@value":"id"},{"@type":"g:Int32","@value":1},
+					{"@type":"g:Direction","@value":"OUT"},"x"]},
+				{"@type":"gx:Duration","@value":"PT1H2M3.5S"}
+			]},"meta":{"@type":"g:Map","@value":[]}}}`
+		resp, err := newTestGraphSONSerializer().deserializeMessage(strings.NewReader(message))
+		assert.Nil(t, err)
+		assert.Equal(t, uuid.MustParse("41d2e28a-20a4-4ab0-b379-d810dede3786"), resp.responseID)
+		assert.Equal(t, uint16(200), resp.responseStatus.code)
+		assert.Equal(t, map[string]interface{}{"host": "/127.0.0.1:62742"}, resp.responseStatus.attributes)
+		assert.Equal(t, map[string]interface{}{}, resp.responseResult.meta)
+
+		data := resp.responseResult.data.([]interface{})
+		assert.Len(t, data, 7)
+		traverser := data[0].(*Traverser)
+		assert.Equal(t, int64(2), traverser.bulk)
+		vertex := traverser.value.(*Vertex)
+		assert.Equal(t, int32(1), vertex.Id)
+		assert.Equal(t, "person", vertex.Label)
+		properties := vertex.Properties.([]interface{})
+		assert.Len(t, properties, 2)
+		age := properties[0].(*VertexProperty)
+		assert.Equal(t, "age", age.Label)
+		assert.Equal(t, int32(29), age.Value)
+		assert.Equal(t, []interface{}{&Property{Key: "since", Value: int32(2010)}}, age.Properties)
+		assert.Equal(t, "marko", properties[1].(*VertexProperty).Value)
+
+		edge := data[1].(*Traverser).value.(*Edge)
+		assert.Equal(t, int32(7), edge.Id)
+		assert.Equal(t, int32(2), edge.InV.Id)
+		assert.Equal(t, int32(1), edge.OutV.Id)
+		assert.Equal(t, []interface{}{&Property{Key: "weight", Value: 0.5}}, edge.Properties)
+
+		assert.Equal(t, []interface{}{"a", "a", "b"}, data[2].(*BulkSet).Expand())
+		bigDecimal := data[3].(*BigDecimal)
+		assert.Equal(t, int32(2), bigDecimal.Scale)
+		assert.Equal(t, "12345678901234567890123456789012", bigDecimal.UnscaledValue.String())
+		path := data[4].(*Path)
+		assert.Equal(t, []interface{}{"marko"}, path.Objects)
+		assert.Equal(t, map[interface{}]interface{}{"id": int32(1), "OUT": "x"}, data[5])
+		assert.Equal(t, time.Hour+2*time.Minute+3500*time.Millisecond, data[6])
+	})
+
+	t.Run("Test deserializeMessage error response", func(t *testing.T) {
+		message := `{"requestId":{"@type":"g:UUID","@value":"41d2e28a-20a4-4ab0-b379-d810dede3786"},
+			"status":{"message":"Division by zero","code":597,"attributes":{"@type":"g:Map","@value":[
+				"exceptions",{"@type":"g:List","@value":["java.lang.ArithmeticException"]},"stackTrace","trace"]}},
+			"result":{"data":null,"meta":{"@type":"g:Map","@value":[]}}}`
+		resp, err := newTestGraphSONSerializer().deserializeMessage(strings.NewReader(message))
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(597), resp.responseStatus.code)
+		assert.Equal(t, "Division by zero", resp.responseStatus.message)
+		responseError := newResponseError(resp)
+		assert.Equal(t, []string{"java.lang.ArithmeticException"}, responseError.Exceptions)
+		assert.Equal(t, "trace", responseError.StackTrace)
+	})
+
+	t.Run("Test deserializeMessage metrics", func(t *testing.T) {
+		message := `{"requestId":"41d2e28a-20a4-4ab0-b379-d810dede3786","status":{"message":"","code":200,"attributes":{"@type":"g:Map","@value":[]}},
+			"result":{"data":{"@type":"g:List","@value":[{"@type":"g:TraversalMetrics","@value":{"@type":"g:Map","@value":[
+				"dur",{"@type":"g:Double","@value":1.5},
+				"metrics",{"@type":"g:List","@value":[{"@type":"g:Metrics","@value":{"@type":"g:Map","@value":[
+					"dur",{"@type":"g:Double","@value":0.5},
+					"counts",{"@type":"g:Map","@value":["traverserCount",{"@type":"g:Int64","@value":4}]},
+					"name","TinkerGraphStep(vertex,[])",
+					"annotations",{"@type":"g:Map","@value":["percentDur",{"@type":"g:Double","@value":33.3}]},
+					"id","0.0.0()"]}}]}]}}]},"meta":{"@type":"g:Map","@value":[]}}}`
+		resp, err := newTestGraphSONSerializer().deserializeMessage(strings.NewReader(message))
+		assert.Nil(t, err)
+		metrics := resp.responseResult.data.([]interface{})[0].(*TraversalMetrics)
+		assert.Equal(t, int64(1500000), metrics.Duration)
+		assert.Len(t, metrics.Metrics, 1)
+		assert.Equal(t, "0.0.0()", metrics.Metrics[0].Id)
+		assert.Equal(t, "TinkerGraphStep(vertex,[])", metrics.Metrics[0].Name)
+		assert.Equal(t, int64(500000), metrics.Metrics[0].Duration)
+		assert.Equal(t, map[string]int64{"traverserCount": 4}, metrics.Metrics[0].Counts)
+		assert.Equal(t, map[string]interface{}{"percentDur": 33.3}, metrics.Metrics[0].Annotations)
+	})
+
+	t.Run("Test deserializeMessage subgraph", func(t *testing.T) {
+		message := `{"requestId":"41d2e28a-20a4-4ab0-b379-d810dede3786","status":{"code":200},
+			"result":{"data":{"@type":"g:List","@value":[{"@type":"tinker:graph","@value":{
+			"vertices":[
+				{"@type":"g:Vertex","@value":{"id":{"@type":"g:Int32","@value":1},"label":"person","properties":{"name":[
+					{"@type":"g:VertexProperty","@value":{"id":{"@type":"g:Int64","@value":0},"value":"marko","label":"name"}}]}}},
+				{"@type":"g:Vertex","@value":{"id":{"@type":"g:Int32","@value":3},"label":"software"}}],
+			"edges":[
+				{"@type":"g:Edge","@value":{"id":{"@type":"g:Int32","@value":9},"label":"created",
+					"inVLabel":"software","outVLabel":"person",
+					"inV":{"@type":"g:Int32","@value":3},"outV":{"@type":"g:Int32","@value":1},
+					"properties":{"weight":{"@type":"g:Property","@value":{"key":"weight","value":{"@type":"g:Double","@value":0.4}}}}}}]
+			}}]},"meta":{"@type":"g:Map","@value":[]}}}`
+		resp, err := newTestGraphSONSerializer().deserializeMessage(strings.NewReader(message))
+		assert.Nil(t, err)
+		g := resp.responseResult.data.([]interface{})[0].(*Graph)
+		assert.Len(t, g.Vertices(), 2)
+		assert.Equal(t, "marko", g.Vertex(int32(1)).Properties.([]interface{})[0].(*VertexProperty).Value)
+		assert.Equal(t, 0.4, g.Edge(int32(9)).Properties.([]interface{})[0].(*Property).Value)
+		assert.Same(t, g.Vertex(int32(3)), g.AdjacentVertices(int32(1), Direction.Out, "created")[0])
+	})
+
+	t.Run("Test deserializeMessage unknown type", func(t *testing.T) {
+		message := `{"requestId":"41d2e28a-20a4-4ab0-b379-d810dede3786","status":{"code":200},
+			"result":{"data":{"@type":"x:Unknown","@value":1}}}`
+		_, err := newTestGraphSONSerializer().deserializeMessage(strings.NewReader(message))
+		assert.True(t, isSameErrorCode(err, newError(E1202GraphSONReadUnknownTypeError)))
+	})
+
+	t.Run("Test ISO durations", func(t *testing.T) {
+		for text, duration := range map[string]time.Duration{
+			"PT0S":      0,
+			"PT1.5S":    1500 * time.Millisecond,
+			"PT-0.001S": -time.Millisecond,
+			"PT90061S":  25*time.Hour + time.Minute + time.Second,
+		} {
+			assert.Equal(t, text, formatISODuration(duration))
+			parsed, err := parseISODuration(text)
+			assert.Nil(t, err)
+			assert.Equal(t, duration, parsed)
+		}
+		parsed, err := parseISODuration("P1DT2H-3M4.000000005S")
+		assert.Nil(t, err)
+		assert.Equal(t, 26*time.Hour-3*time.Minute+4*time.Second+5, parsed)
+		_, err = parseISODuration("1 hour")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Test BigDecimal formatting", func(t *testing.T) {
+		for text, bigDecimal := range map[string]*BigDecimal{
+			"0.05":     {Scale: 2, UnscaledValue: *big.NewInt(5)},
+			"-1.25":    {Scale: 2, UnscaledValue: *big.NewInt(-125)},
+			"42":       {Scale: 0, UnscaledValue: *big.NewInt(42)},
+			"42E+3":    {Scale: -3, UnscaledValue: *big.NewInt(42)},
+			"-0.00001": {Scale: 5, UnscaledValue: *big.NewInt(-1)},
+		} {
+			assert.Equal(t, text, formatBigDecimal(bigDecimal))
+			parsed, err := parseBigDecimal(text)
+			assert.Nil(t, err)
+			assert.Equal(t, bigDecimal, parsed)
+		}
+		parsed, err := parseBigDecimal("1.5e-2")
+		assert.Nil(t, err)
+		assert.Equal(t, &BigDecimal{Scale: 3, UnscaledValue: *big.NewInt(15)}, parsed)
+	})
+
+	t.Run("Test newSerializer", func(t *testing.T) {
+		handler := newLogHandler(&defaultLogger{}, Error, language.English)
+		serializer, err := newSerializer(GraphSONV3, handler)
+		assert.Nil(t, err)
+		assert.IsType(t, graphSONSerializer{}, serializer)
+		serializer, err = newSerializer(GraphBinaryV1, handler)
+		assert.Nil(t, err)
+		assert.IsType(t, graphBinarySerializer{}, serializer)
+		_, err = newSerializer(SerializerType(0), handler)
+		assert.True(t, isSameErrorCode(err, newError(E0705UnknownSerializerTypeError)))
+	})
+}
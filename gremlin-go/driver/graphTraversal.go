@@ -523,8 +523,15 @@ func (g *GraphTraversal) Properties(args ...interface{}) *GraphTraversal {
 	return g
 }
 
-// Property adds the property step to the GraphTraversal.
+// Property adds the property step to the GraphTraversal. Props, optionally preceded by a Cardinality, are added as a
+// step per property with a cardinality and a step of a map of the other properties.
 func (g *GraphTraversal) Property(args ...interface{}) *GraphTraversal {
+	if steps, ok := propertySteps(args); ok {
+		for _, step := range steps {
+			g.Bytecode.AddStep("property", step...)
+		}
+		return g
+	}
 	g.Bytecode.AddStep("property", args...)
 	return g
 }
@@ -752,7 +759,7 @@ func (t *Transaction) Begin() (*GraphTraversalSource, error) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	if err := t.verifyTransactionState(false, newError(err1101TransactionRepeatedOpenError)); err != nil {
+	if err := t.verifyTransactionState(false, newError(E1101TransactionRepeatedOpenError)); err != nil {
 		return nil, err
 	}
 
@@ -774,7 +781,7 @@ func (t *Transaction) Rollback() error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	if err := t.verifyTransactionState(true, newError(err1102TransactionRollbackNotOpenedError)); err != nil {
+	if err := t.verifyTransactionState(true, newError(E1102TransactionRollbackNotOpenedError)); err != nil {
 		return err
 	}
 
@@ -785,7 +792,7 @@ func (t *Transaction) Commit() error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	if err := t.verifyTransactionState(true, newError(err1103TransactionCommitNotOpenedError)); err != nil {
+	if err := t.verifyTransactionState(true, newError(E1103TransactionCommitNotOpenedError)); err != nil {
 		return err
 	}
 
@@ -796,7 +803,7 @@ func (t *Transaction) Close() error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	if err := t.verifyTransactionState(true, newError(err1104TransactionRepeatedCloseError)); err != nil {
+	if err := t.verifyTransactionState(true, newError(E1104TransactionRepeatedCloseError)); err != nil {
 		return err
 	}
 
@@ -87,7 +87,7 @@ func TestGraphStructureFunctions(t *testing.T) {
 		p := Path{keys, data}
 		val, err := p.GetPathObject("foo")
 		assert.Nil(t, val)
-		assert.Equal(t, newError(err0301GetPathObjectInvalidPathUnequalLengthsError), err)
+		assert.Equal(t, newError(E0301GetPathObjectInvalidPathUnequalLengthsError), err)
 	})
 
 	t.Run("Test Path.GetPathObject() with invalid key", func(t *testing.T) {
@@ -96,7 +96,7 @@ func TestGraphStructureFunctions(t *testing.T) {
 		p := Path{keys, data}
 		val, err := p.GetPathObject("foobar")
 		assert.Nil(t, val)
-		assert.Equal(t, newError(err0303GetPathNoLabelFoundError, "foobar"), err)
+		assert.Equal(t, newError(E0303GetPathNoLabelFoundError, "foobar"), err)
 	})
 
 	t.Run("Test Path.GetPathObject() with Non-string value in labels", func(t *testing.T) {
@@ -105,7 +105,7 @@ func TestGraphStructureFunctions(t *testing.T) {
 		p := Path{keys, data}
 		val, err := p.GetPathObject("bar")
 		assert.Nil(t, val)
-		assert.Equal(t, newError(err0302GetPathObjectInvalidPathNonStringLabelError), err)
+		assert.Equal(t, newError(E0302GetPathObjectInvalidPathNonStringLabelError), err)
 	})
 
 	t.Run("Test Path.GetPathObject() with multiple object return", func(t *testing.T) {
@@ -177,3 +177,105 @@ func TestCustomStructs(t *testing.T) {
 		})
 	})
 }
+
+// newTestGraph returns a part of the modern graph: marko knows vadas and josh and created lop, josh created lop.
+func newTestGraph() *Graph {
+	g := NewGraph()
+	vertex := func(id int32, label string, name string) *Vertex {
+		v := &Vertex{Element{Id: id, Label: label}}
+		v.Properties = []interface{}{&VertexProperty{Element: Element{Id: int64(id) * 10, Label: "name",
+			Properties: []interface{}{&Property{Key: "acl", Value: "public"}}},
+			Key: "name", Value: name, Vertex: Vertex{Element{Id: id, Label: label}}}}
+		return v
+	}
+	edge := func(id int32, out *Vertex, label string, in *Vertex, weight float64) *Edge {
+		return &Edge{Element: Element{Id: id, Label: label, Properties: []interface{}{&Property{Key: "weight", Value: weight}}},
+			OutV: Vertex{Element{Id: out.Id, Label: out.Label}}, InV: Vertex{Element{Id: in.Id, Label: in.Label}}}
+	}
+	marko := vertex(1, "person", "marko")
+	vadas := vertex(2, "person", "vadas")
+	lop := vertex(3, "software", "lop")
+	josh := vertex(4, "person", "josh")
+	for _, v := range []*Vertex{marko, vadas, lop, josh} {
+		g.AddVertex(v)
+	}
+	g.AddEdge(edge(7, marko, "knows", vadas, 0.5))
+	g.AddEdge(edge(8, marko, "knows", josh, 1.0))
+	g.AddEdge(edge(9, marko, "created", lop, 0.4))
+	g.AddEdge(edge(11, josh, "created", lop, 0.4))
+	return g
+}
+
+func vertexIds(vertices []*Vertex) []interface{} {
+	var ids []interface{}
+	for _, v := range vertices {
+		ids = append(ids, v.Id)
+	}
+	return ids
+}
+
+func edgeIds(edges []*Edge) []interface{} {
+	var ids []interface{}
+	for _, e := range edges {
+		ids = append(ids, e.Id)
+	}
+	return ids
+}
+
+func TestGraph(t *testing.T) {
+	t.Run("Test lookups", func(t *testing.T) {
+		g := newTestGraph()
+		assert.Len(t, g.Vertices(), 4)
+		assert.Len(t, g.Edges(), 4)
+		assert.Equal(t, "josh", g.Vertex(int32(4)).Properties.([]interface{})[0].(*VertexProperty).Value)
+		assert.Nil(t, g.Vertex(int64(4)))
+		assert.Equal(t, "created", g.Edge(int32(11)).Label)
+		assert.Nil(t, g.Edge(int32(1)))
+		assert.Equal(t, []interface{}{int32(1), int32(2), int32(4)}, vertexIds(g.VerticesByLabel("person")))
+		assert.Equal(t, []interface{}{int32(9), int32(11)}, edgeIds(g.EdgesByLabel("created")))
+		assert.Nil(t, g.VerticesByLabel("dog"))
+	})
+
+	t.Run("Test adjacency", func(t *testing.T) {
+		g := newTestGraph()
+		assert.Equal(t, []interface{}{int32(7), int32(8), int32(9)}, edgeIds(g.VertexEdges(int32(1), Direction.Out)))
+		assert.Equal(t, []interface{}{int32(7), int32(8)}, edgeIds(g.VertexEdges(int32(1), Direction.Out, "knows")))
+		assert.Nil(t, g.VertexEdges(int32(1), Direction.In))
+		assert.Equal(t, []interface{}{int32(11), int32(8)}, edgeIds(g.VertexEdges(int32(4), Direction.Both)))
+		assert.Equal(t, []interface{}{int32(2), int32(4), int32(3)}, vertexIds(g.AdjacentVertices(int32(1), Direction.Out)))
+		assert.Equal(t, []interface{}{int32(1), int32(4)}, vertexIds(g.AdjacentVertices(int32(3), Direction.In, "created")))
+		assert.Equal(t, []interface{}{int32(3), int32(1)}, vertexIds(g.AdjacentVertices(int32(4), Direction.Both)))
+		assert.Same(t, g.Vertex(int32(3)), g.AdjacentVertices(int32(4), Direction.Out)[0])
+	})
+
+	t.Run("Test self-loop and vertices outside of the graph", func(t *testing.T) {
+		g := NewGraph()
+		g.AddVertex(&Vertex{Element{Id: "a", Label: "node"}})
+		g.AddEdge(&Edge{Element: Element{Id: "loop", Label: "self"}, OutV: Vertex{Element{Id: "a"}}, InV: Vertex{Element{Id: "a"}}})
+		g.AddEdge(&Edge{Element: Element{Id: "out", Label: "to"}, OutV: Vertex{Element{Id: "a"}}, InV: Vertex{Element{Id: "b", Label: "node"}}})
+		assert.Equal(t, []interface{}{"loop", "out"}, edgeIds(g.VertexEdges("a", Direction.Both)))
+		assert.Equal(t, []interface{}{"a", "b"}, vertexIds(g.AdjacentVertices("a", Direction.Both)))
+		assert.Equal(t, []interface{}{"a"}, vertexIds(g.AdjacentVertices("a", Direction.In)))
+		assert.Equal(t, "node", g.AdjacentVertices("a", Direction.Out, "to")[0].Label)
+		assert.Nil(t, g.Vertex("b"))
+	})
+
+	t.Run("Test replace elements", func(t *testing.T) {
+		g := newTestGraph()
+		g.AddVertex(&Vertex{Element{Id: int32(2), Label: "dog"}})
+		assert.Len(t, g.Vertices(), 4)
+		assert.Equal(t, "dog", g.Vertices()[1].Label)
+		g.AddEdge(&Edge{Element: Element{Id: int32(7), Label: "likes"}, OutV: Vertex{Element{Id: int32(2)}}, InV: Vertex{Element{Id: int32(1)}}})
+		assert.Len(t, g.Edges(), 4)
+		assert.Equal(t, []interface{}{int32(8), int32(9)}, edgeIds(g.VertexEdges(int32(1), Direction.Out)))
+		assert.Equal(t, []interface{}{int32(7)}, edgeIds(g.VertexEdges(int32(1), Direction.In)))
+	})
+
+	t.Run("Test zero value and non-comparable ids", func(t *testing.T) {
+		var g Graph
+		assert.Nil(t, g.Vertex(int32(1)))
+		assert.Nil(t, g.VertexEdges(int32(1), Direction.Both))
+		g.AddVertex(&Vertex{Element{Id: map[string]interface{}{"key": int32(1)}, Label: "composite"}})
+		assert.Equal(t, "composite", g.Vertex(map[string]interface{}{"key": int32(1)}).Label)
+	})
+}
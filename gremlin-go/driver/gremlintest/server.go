@@ -0,0 +1,362 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package gremlintest provides a stand-in for Gremlin Server to test code using gremlingo without a running server.
+// The Server speaks GraphBinary over websockets and answers requests with canned responses registered per script or
+// per traversal.
+package gremlintest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/apache/tinkerpop/gremlin-go/v3/driver/internal/wire"
+	"github.com/gorilla/websocket"
+)
+
+// Status codes sent by the Server, as defined by Gremlin Server.
+const (
+	StatusSuccess             = 200
+	StatusNoContent           = 204
+	StatusPartialContent      = 206
+	StatusUnauthorized        = 401
+	StatusAuthenticate        = 407
+	StatusMalformedRequest    = 498
+	StatusInvalidRequestArgs  = 499
+	StatusServerError         = 500
+	StatusScriptEvaluation    = 597
+	StatusServerTimeout       = 598
+	StatusServerSerialization = 599
+)
+
+// DefaultBatchSize is the number of results per response if neither the Server nor the request sets a batch size.
+const DefaultBatchSize = 64
+
+// Response is a canned response of the Server.
+type Response struct {
+	// Results are sent in batches, all but the last one with status 206 (Partial Content) and the last one with status
+	// 200 (Success). If there are no results, a single response with status 204 (No Content) is sent.
+	Results []interface{}
+	// StatusCode replaces the status of the last response if it is set. An error status can be sent with or without
+	// Results.
+	StatusCode       int
+	StatusMessage    string
+	StatusAttributes map[string]interface{}
+}
+
+// Results creates a successful Response with the given results.
+func Results(results ...interface{}) *Response {
+	return &Response{Results: results}
+}
+
+// Error creates a Response failing with the given status.
+func Error(statusCode int, message string) *Response {
+	return &Response{StatusCode: statusCode, StatusMessage: message}
+}
+
+type cannedBytecode struct {
+	bytecode interface{}
+	response *Response
+}
+
+type credentials struct {
+	username string
+	password string
+}
+
+// Server is a websocket server answering gremlingo requests with canned responses. Scripts are matched by their exact
+// text and traversals by their bytecode. Requests which do not match any canned response are answered with the
+// Response set by OnUnmatched, which fails with status 500 (Server Error) by default.
+type Server struct {
+	// URL is the url of the websocket endpoint to connect to, such as ws://127.0.0.1:49152/gremlin.
+	URL string
+
+	httpServer  *httptest.Server
+	upgrader    websocket.Upgrader
+	lock        sync.Mutex
+	batchSize   int
+	scripts     map[string]*Response
+	bytecodes   []cannedBytecode
+	unmatched   *Response
+	credentials *credentials
+	requests    []*Request
+	conns       map[*websocket.Conn]bool
+}
+
+// Request is a request as it is received by the Server.
+type Request = wire.RequestMessage
+
+// NewServer starts a Server. It must be closed after use.
+func NewServer() *Server {
+	server := &Server{
+		batchSize: DefaultBatchSize,
+		scripts:   map[string]*Response{},
+		unmatched: Error(StatusServerError, "no response is registered for the request"),
+		conns:     map[*websocket.Conn]bool{},
+	}
+	server.httpServer = httptest.NewServer(http.HandlerFunc(server.serveWebsocket))
+	server.URL = strings.Replace(server.httpServer.URL, "http://", "ws://", 1) + "/gremlin"
+	return server
+}
+
+// Close closes all connections and shuts the Server down.
+func (server *Server) Close() {
+	server.lock.Lock()
+	for conn := range server.conns {
+		_ = conn.Close()
+	}
+	server.lock.Unlock()
+	server.httpServer.Close()
+}
+
+// SetBatchSize sets the number of results per response, unless a request specifies its own batchSize.
+// Default: 64
+func (server *Server) SetBatchSize(batchSize int) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.batchSize = batchSize
+}
+
+// OnScript registers the Response to a script submitted with Client.Submit.
+func (server *Server) OnScript(script string, response *Response) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.scripts[script] = response
+}
+
+// OnBytecode registers the Response to a traversal, such as g.V().Count().Bytecode. The traversal has to be
+// serializable with GraphBinary.
+func (server *Server) OnBytecode(bytecode *gremlingo.Bytecode, response *Response) error {
+	// Bytecode is compared as deserialized on the Server, so that arguments match regardless of their Go types.
+	serialized, err := wire.MarshalGraphBinary(bytecode)
+	if err != nil {
+		return err
+	}
+	canonical, err := wire.UnmarshalGraphBinary(serialized)
+	if err != nil {
+		return err
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.bytecodes = append(server.bytecodes, cannedBytecode{bytecode: canonical, response: response})
+	return nil
+}
+
+// OnUnmatched sets the Response to requests for which no Response is registered.
+func (server *Server) OnUnmatched(response *Response) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.unmatched = response
+}
+
+// RequireAuth makes the Server challenge every connection for SASL PLAIN credentials before answering requests, as
+// Gremlin Server does with its SimpleAuthenticator.
+func (server *Server) RequireAuth(username string, password string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.credentials = &credentials{username: username, password: password}
+}
+
+// Requests returns the requests received so far, including authentication and session requests.
+func (server *Server) Requests() []*Request {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]*Request{}, server.requests...)
+}
+
+func (server *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := server.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	server.lock.Lock()
+	server.conns[conn] = true
+	server.lock.Unlock()
+	defer func() {
+		server.lock.Lock()
+		delete(server.conns, conn)
+		server.lock.Unlock()
+		_ = conn.Close()
+	}()
+
+	session := &serverConn{server: server, conn: conn}
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		request, err := wire.ReadGraphBinaryRequest(message)
+		if err != nil {
+			// Without a request id, the request cannot be answered.
+			return
+		}
+		if err = session.handle(request); err != nil {
+			return
+		}
+	}
+}
+
+// serverConn holds the state of a websocket connection to the Server.
+type serverConn struct {
+	server        *Server
+	conn          *websocket.Conn
+	authenticated bool
+	pending       *Request
+}
+
+func (session *serverConn) handle(request *Request) error {
+	server := session.server
+	server.lock.Lock()
+	server.requests = append(server.requests, request)
+	credentials := server.credentials
+	server.lock.Unlock()
+
+	if request.Op == "authentication" {
+		if session.pending == nil {
+			return session.send(request, Error(StatusMalformedRequest, "no request awaits authentication"), nil)
+		}
+		pending := session.pending
+		session.pending = nil
+		if credentials == nil || !credentials.matchSasl(request.Args["sasl"]) {
+			return session.send(pending, Error(StatusUnauthorized, "Username and/or password are incorrect"), nil)
+		}
+		session.authenticated = true
+		request = pending
+	} else if credentials != nil && !session.authenticated {
+		session.pending = request
+		return session.send(request, &Response{StatusCode: StatusAuthenticate}, nil)
+	}
+
+	if request.Op == "close" {
+		return session.send(request, &Response{}, nil)
+	}
+	return session.send(request, server.match(request), request.Args["batchSize"])
+}
+
+func (c *credentials) matchSasl(sasl interface{}) bool {
+	encoded, ok := sasl.(string)
+	if !ok {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+	// SASL PLAIN: authorization identity, authentication identity and password separated by NUL.
+	parts := strings.Split(string(decoded), "\x00")
+	return len(parts) == 3 && parts[1] == c.username && parts[2] == c.password
+}
+
+func (server *Server) match(request *Request) *Response {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	switch gremlin := request.Args["gremlin"].(type) {
+	case string:
+		if response, ok := server.scripts[gremlin]; ok {
+			return response
+		}
+	case *gremlingo.Bytecode:
+		for _, canned := range server.bytecodes {
+			if reflect.DeepEqual(canned.bytecode, gremlin) {
+				return canned.response
+			}
+		}
+	}
+	return server.unmatched
+}
+
+// send writes the response to the request in batches.
+func (session *serverConn) send(request *Request, response *Response, requestBatchSize interface{}) error {
+	session.server.lock.Lock()
+	batchSize := session.server.batchSize
+	session.server.lock.Unlock()
+	if size, ok := toInt(requestBatchSize); ok && size > 0 {
+		batchSize = size
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	results := response.Results
+	for {
+		statusCode := StatusPartialContent
+		batch := results
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		} else if response.StatusCode != 0 {
+			statusCode = response.StatusCode
+		} else if len(batch) == 0 {
+			statusCode = StatusNoContent
+		} else {
+			statusCode = StatusSuccess
+		}
+		results = results[len(batch):]
+
+		message := &wire.ResponseMessage{
+			RequestID:  request.RequestID,
+			StatusCode: uint16(statusCode),
+		}
+		if len(batch) > 0 {
+			message.Data = batch
+		}
+		if statusCode != StatusPartialContent {
+			message.StatusMessage = response.StatusMessage
+			message.StatusAttributes = response.StatusAttributes
+		}
+		serialized, err := wire.WriteGraphBinaryResponse(message)
+		if err != nil {
+			// Results which cannot be serialized fail the request, as on Gremlin Server.
+			serialized, err = wire.WriteGraphBinaryResponse(&wire.ResponseMessage{
+				RequestID:     request.RequestID,
+				StatusCode:    StatusServerSerialization,
+				StatusMessage: err.Error(),
+			})
+			if err != nil {
+				return err
+			}
+			statusCode = StatusServerSerialization
+		}
+		if err = session.conn.WriteMessage(websocket.BinaryMessage, serialized); err != nil {
+			return err
+		}
+		if statusCode != StatusPartialContent {
+			return nil
+		}
+	}
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
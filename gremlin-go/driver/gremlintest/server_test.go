@@ -0,0 +1,295 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlintest
+
+import (
+	"errors"
+	"testing"
+
+	gremlingo "github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(t *testing.T, server *Server, configurations ...func(settings *gremlingo.ClientSettings)) *gremlingo.Client {
+	client, err := gremlingo.NewClient(server.URL, append([]func(settings *gremlingo.ClientSettings){
+		func(settings *gremlingo.ClientSettings) {
+			settings.LogVerbosity = gremlingo.Off
+		}}, configurations...)...)
+	assert.Nil(t, err)
+	return client
+}
+
+func TestServer(t *testing.T) {
+	t.Run("Test script response", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.OnScript("g.V().values('name')", Results("marko", "vadas"))
+
+		client := newClient(t, server)
+		defer client.Close()
+		resultSet, err := client.Submit("g.V().values('name')")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, "marko", results[0].GetString())
+		assert.Equal(t, "vadas", results[1].GetString())
+
+		requests := server.Requests()
+		assert.Equal(t, 1, len(requests))
+		assert.Equal(t, "eval", requests[0].Op)
+	})
+
+	t.Run("Test bytecode response", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+
+		remote, err := gremlingo.NewDriverRemoteConnection(server.URL, func(settings *gremlingo.DriverRemoteConnectionSettings) {
+			settings.LogVerbosity = gremlingo.Off
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := gremlingo.Traversal_().WithRemote(remote)
+
+		err = server.OnBytecode(g.V().Has("age", gremlingo.P.Gt(30)).Order().By("age", gremlingo.Order.Desc).
+			Values("name").Bytecode, Results("peter", "josh"))
+		assert.Nil(t, err)
+		err = server.OnBytecode(g.V().Count().Bytecode, Results(int64(6)))
+		assert.Nil(t, err)
+
+		names, err := g.V().Has("age", gremlingo.P.Gt(int64(30))).Order().By("age", gremlingo.Order.Desc).
+			Values("name").ToList()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(names))
+		assert.Equal(t, "peter", names[0].GetString())
+
+		count, err := g.V().Count().Next()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(6), count.GetInterface())
+
+		_, err = g.E().Count().Next()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Test props traversal", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+
+		remote, err := gremlingo.NewDriverRemoteConnection(server.URL, func(settings *gremlingo.DriverRemoteConnectionSettings) {
+			settings.LogVerbosity = gremlingo.Off
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := gremlingo.Traversal_().WithRemote(remote)
+
+		err = server.OnBytecode(g.MergeV(map[interface{}]interface{}{"name": "marko", "nicks": []interface{}{"okram"}}).
+			Id().Bytecode, Results(int64(1)))
+		assert.Nil(t, err)
+
+		type person struct {
+			Name  string   `gremlin:"name"`
+			Nicks []string `gremlin:"nicks"`
+		}
+		id, err := g.MergeV(gremlingo.Props(person{"marko", []string{"okram"}})).Id().Next()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), id.GetInterface())
+
+		// The properties are received as a plain map, which Gremlin Server evaluates as it is.
+		requests := server.Requests()
+		assert.Equal(t, 1, len(requests))
+		script, err := gremlingo.NewTranslator("g", gremlingo.GremlinGroovy).
+			Translate(requests[0].Args["gremlin"].(*gremlingo.Bytecode))
+		assert.Nil(t, err)
+		assert.Equal(t, "g.mergeV(['name':'marko','nicks':['okram']]).id()", script)
+	})
+
+	t.Run("Test script only traversal", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.OnScript("g.V().has(_args_0,P.gt(_args_1)).values(_args_2)", Results("peter", "josh"))
+
+		remote, err := gremlingo.NewDriverRemoteConnection(server.URL, func(settings *gremlingo.DriverRemoteConnectionSettings) {
+			settings.LogVerbosity = gremlingo.Off
+			settings.ScriptOnly = true
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := gremlingo.Traversal_().WithRemote(remote)
+
+		names, err := g.V().Has("age", gremlingo.P.Gt(int64(30))).Values("name").ToList()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(names))
+		assert.Equal(t, "peter", names[0].GetString())
+
+		requests := server.Requests()
+		assert.Equal(t, 1, len(requests))
+		assert.Equal(t, "eval", requests[0].Op)
+		assert.Equal(t, map[interface{}]interface{}{"_args_0": "age", "_args_1": int64(30), "_args_2": "name"},
+			requests[0].Args["bindings"])
+	})
+
+	t.Run("Test script only traversal with max parameters", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.OnScript("g.V().has(_args_0,P.gt(30L)).values('name')", Results("peter", "josh"))
+
+		remote, err := gremlingo.NewDriverRemoteConnection(server.URL, func(settings *gremlingo.DriverRemoteConnectionSettings) {
+			settings.LogVerbosity = gremlingo.Off
+			settings.ScriptOnly = true
+			settings.ScriptOnlyMaxParameters = 1
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := gremlingo.Traversal_().WithRemote(remote)
+
+		names, err := g.V().Has("age", gremlingo.P.Gt(int64(30))).Values("name").ToList()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(names))
+
+		requests := server.Requests()
+		assert.Equal(t, 1, len(requests))
+		assert.Equal(t, map[interface{}]interface{}{"_args_0": "age"}, requests[0].Args["bindings"])
+	})
+
+	t.Run("Test batched response", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.SetBatchSize(2)
+		server.OnScript("g.V().id()", Results(int32(1), int32(2), int32(3), int32(4), int32(5)))
+
+		client := newClient(t, server)
+		defer client.Close()
+		resultSet, err := client.Submit("g.V().id()")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(results))
+		for i, result := range results {
+			assert.Equal(t, int32(i+1), result.GetInterface())
+		}
+
+		resultSet, err = client.SubmitWithOptions("g.V().id()", new(gremlingo.RequestOptionsBuilder).
+			SetBatchSize(4).Create())
+		assert.Nil(t, err)
+		results, err = resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(results))
+	})
+
+	t.Run("Test iterating a batched traversal", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.SetBatchSize(2)
+
+		remote, err := gremlingo.NewDriverRemoteConnection(server.URL, func(settings *gremlingo.DriverRemoteConnectionSettings) {
+			settings.LogVerbosity = gremlingo.Off
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := gremlingo.Traversal_().WithRemote(remote)
+		err = server.OnBytecode(g.V().Id().Bytecode, Results(int32(1), int32(2), int32(3), int32(4), int32(5)))
+		assert.Nil(t, err)
+		err = server.OnBytecode(g.V().Fail().Bytecode, Error(StatusScriptEvaluation, "fail() step triggered"))
+		assert.Nil(t, err)
+
+		var ids []int64
+		gremlingo.AllAs[int64](g.V().Id())(func(id int64, err error) bool {
+			assert.Nil(t, err)
+			ids = append(ids, id)
+			return true
+		})
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+
+		count := 0
+		g.V().Id().All()(func(result *gremlingo.Result, err error) bool {
+			count++
+			return count < 3
+		})
+		assert.Equal(t, 3, count)
+
+		var errs []error
+		g.V().Fail().All()(func(result *gremlingo.Result, err error) bool {
+			errs = append(errs, err)
+			return true
+		})
+		assert.Equal(t, 1, len(errs))
+		assert.Contains(t, errs[0].Error(), "fail() step triggered")
+	})
+
+	t.Run("Test error response", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.OnScript("g.V().fail()", Error(StatusScriptEvaluation, "fail() step triggered"))
+
+		client := newClient(t, server)
+		defer client.Close()
+		resultSet, err := client.Submit("g.V().fail()")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "fail() step triggered")
+		var responseError *gremlingo.ResponseError
+		assert.True(t, errors.As(err, &responseError))
+		assert.Equal(t, uint16(StatusScriptEvaluation), responseError.StatusCode)
+
+		resultSet, err = client.Submit("g.V()")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "no response is registered")
+
+		server.OnUnmatched(Results())
+		resultSet, err = client.Submit("g.V()")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(results))
+	})
+
+	t.Run("Test authentication", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.RequireAuth("stephen", "password")
+		server.OnScript("1+1", Results(int32(2)))
+
+		client := newClient(t, server, func(settings *gremlingo.ClientSettings) {
+			settings.AuthInfo = gremlingo.BasicAuthInfo("stephen", "password")
+		})
+		defer client.Close()
+		resultSet, err := client.Submit("1+1")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), results[0].GetInterface())
+
+		requests := server.Requests()
+		assert.Equal(t, 2, len(requests))
+		assert.Equal(t, "authentication", requests[1].Op)
+
+		wrongPassword := newClient(t, server, func(settings *gremlingo.ClientSettings) {
+			settings.AuthInfo = gremlingo.BasicAuthInfo("stephen", "wrong")
+		})
+		defer wrongPassword.Close()
+		resultSet, err = wrongPassword.Submit("1+1")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+	})
+}
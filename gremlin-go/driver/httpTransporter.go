@@ -0,0 +1,395 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// httpRequestIDLength is the length of the request id which precedes the body of requests serialized by httpSerializer.
+const httpRequestIDLength = 16
+
+// httpSerializer serializes requests into the JSON body accepted by the HTTP endpoint of Gremlin Server, which only
+// evaluates scripts outside of sessions. Traversals are translated to scripts. Responses are deserialized by the
+// serializer of the connection, whose format is requested with the Accept header.
+type httpSerializer struct {
+	serializer
+}
+
+func newHTTPSerializer(serializerType SerializerType, handler *logHandler) (serializer, error) {
+	responseSerializer, err := newSerializer(serializerType, handler)
+	if err != nil {
+		return nil, err
+	}
+	return httpSerializer{responseSerializer}, nil
+}
+
+// serializeMessage writes the id of the request followed by the JSON body. The id is not sent, but used by the
+// httpTransporter to match the response, to which the server assigns a new id, with the request.
+func (hs httpSerializer) serializeMessage(request *request) ([]byte, error) {
+	if _, ok := request.args["session"]; ok {
+		return nil, newError(E0803HttpTransporterUnsupportedRequestError, "session")
+	}
+	body := map[string]interface{}{}
+	var bindings map[string]interface{}
+	switch gremlin := request.args["gremlin"].(type) {
+	case string:
+		body["gremlin"] = gremlin
+		bindings, _ = request.args["bindings"].(map[string]interface{})
+	case Bytecode:
+		script, err := NewTranslator("g", GremlinGroovy).Translate(&gremlin)
+		if err != nil {
+			return nil, err
+		}
+		body["gremlin"] = script
+		bindings = gremlin.bindings
+	default:
+		return nil, newError(E0803HttpTransporterUnsupportedRequestError, request.op)
+	}
+	if len(bindings) > 0 {
+		values := make(map[string]interface{}, len(bindings))
+		for key, value := range bindings {
+			jsonValue, ok := httpBindingValue(value)
+			if !ok {
+				return nil, newError(E0804HttpTransporterBindingValueError, key, value)
+			}
+			values[key] = jsonValue
+		}
+		body["bindings"] = values
+	}
+	if aliases, ok := request.args["aliases"]; ok {
+		body["aliases"] = aliases
+	}
+
+	buffer := bytes.Buffer{}
+	buffer.Write(request.requestID[:])
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(body); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// httpBindingValue returns the value of a binding as plain JSON, which is the only form of bindings the HTTP endpoint
+// reads. The server reads integers as Long and floating point numbers as Double.
+func httpBindingValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, string, bool, int8, int16, int32, int64, int, uint8, uint16, uint32, uint, uint64:
+		return v, true
+	case float32:
+		return httpBindingValue(float64(v))
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case *big.Int:
+		return json.Number(v.String()), true
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			jsonItem, ok := httpBindingValue(item)
+			if !ok {
+				return nil, false
+			}
+			items[i] = jsonItem
+		}
+		return items, true
+	case map[string]interface{}:
+		entries := make(map[interface{}]interface{}, len(v))
+		for key, entry := range v {
+			entries[key] = entry
+		}
+		return httpBindingValue(entries)
+	case map[interface{}]interface{}:
+		entries := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			stringKey, ok := key.(string)
+			if !ok {
+				return nil, false
+			}
+			if entries[stringKey], ok = httpBindingValue(entry); !ok {
+				return nil, false
+			}
+		}
+		return entries, true
+	}
+	return nil, false
+}
+
+// httpTransporter sends each serialized request as the body of a POST to the HTTP endpoint of Gremlin Server. Requests
+// are sent concurrently and the response bodies are handed to Read in the order in which they complete.
+type httpTransporter struct {
+	url          string
+	logHandler   *logHandler
+	connSettings *connectionSettings
+	client       *http.Client
+	responses    chan []byte
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           *sync.WaitGroup
+	mutex        sync.Mutex
+	isClosed     bool
+}
+
+func (transporter *httpTransporter) Connect() error {
+	transporter.mutex.Lock()
+	defer transporter.mutex.Unlock()
+
+	if transporter.client != nil {
+		return nil
+	}
+	u, err := url.Parse(transporter.url)
+	if err != nil {
+		return err
+	}
+	// The url of the websocket endpoint may be used as is, as Gremlin Server serves both on the same path.
+	switch u.Scheme {
+	case "ws":
+		u.Scheme = "http"
+	case "wss":
+		u.Scheme = "https"
+	}
+	transporter.url = u.String()
+
+	dialer := &net.Dialer{Timeout: transporter.connSettings.connectionTimeout}
+	transporter.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         dialer.DialContext,
+			TLSClientConfig:     transporter.connSettings.tlsConfig,
+			TLSHandshakeTimeout: transporter.connSettings.connectionTimeout,
+			DisableCompression:  !transporter.connSettings.enableCompression,
+			ReadBufferSize:      transporter.connSettings.readBufferSize,
+			WriteBufferSize:     transporter.connSettings.writeBufferSize,
+		},
+	}
+	transporter.ctx, transporter.cancel = context.WithCancel(context.Background())
+	return nil
+}
+
+func (transporter *httpTransporter) Write(data []byte) error {
+	if err := transporter.Connect(); err != nil {
+		return err
+	}
+	transporter.mutex.Lock()
+	defer transporter.mutex.Unlock()
+	if transporter.isClosed {
+		return newError(E0802HttpTransporterClosedError)
+	}
+	if len(data) < httpRequestIDLength {
+		return newError(E0803HttpTransporterUnsupportedRequestError, "unserialized")
+	}
+
+	transporter.wg.Add(1)
+	go transporter.post(data)
+	return nil
+}
+
+// post sends the request and passes the response on to Read. If no serialized response is received, an error response
+// is built for the request, so that only the ResultSet of this request fails.
+func (transporter *httpTransporter) post(data []byte) {
+	defer transporter.wg.Done()
+
+	response, err := transporter.roundTrip(data)
+	if err != nil {
+		if transporter.ctx.Err() != nil {
+			// The transporter was closed.
+			return
+		}
+		transporter.logHandler.logf(Error, failedToWriteMessage, "HTTP POST", err.Error())
+		response = transporter.errorResponse(data[:httpRequestIDLength], http.StatusInternalServerError, err.Error())
+	}
+
+	select {
+	case transporter.responses <- response:
+	case <-transporter.ctx.Done():
+	}
+}
+
+func (transporter *httpTransporter) roundTrip(data []byte) ([]byte, error) {
+	mimeType := transporter.connSettings.serializerType.mimeType()
+	requestID, body := data[:httpRequestIDLength], data[httpRequestIDLength:]
+	req, err := http.NewRequestWithContext(transporter.ctx, http.MethodPost, transporter.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	authInfo := transporter.getAuthInfo()
+	for name, values := range authInfo.GetHeader() {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if ok, username, password := authInfo.GetBasicAuth(); ok {
+		req.SetBasicAuth(username, password)
+	}
+	if transporter.connSettings.enableUserAgentOnConnect {
+		req.Header.Set(userAgentHeader, userAgent)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", mimeType)
+
+	resp, err := transporter.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), mimeType) {
+		return transporter.withRequestID(requestID, responseBody)
+	}
+	// Errors are not serialized, but reported as JSON.
+	message := http.StatusText(resp.StatusCode)
+	var jsonError struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(responseBody, &jsonError) == nil && jsonError.Message != "" {
+		message = jsonError.Message
+	}
+	statusCode := resp.StatusCode
+	if statusCode < http.StatusBadRequest {
+		statusCode = http.StatusInternalServerError
+	}
+	return transporter.errorResponse(requestID, statusCode, message), nil
+}
+
+// withRequestID returns the response with the id of the request it answers. The server responds with a random id, and
+// encodes GraphBinary responses in base64, as the HTTP endpoint only writes text.
+func (transporter *httpTransporter) withRequestID(requestID []byte, response []byte) ([]byte, error) {
+	if transporter.connSettings.serializerType == GraphSONV3 {
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(response, &envelope); err != nil {
+			return nil, err
+		}
+		id, _ := uuid.FromBytes(requestID)
+		envelope["requestId"], _ = json.Marshal(id.String())
+		return json.Marshal(envelope)
+	}
+
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(response)))
+	n, err := base64.StdEncoding.Decode(decoded, bytes.TrimSpace(response))
+	if err != nil {
+		return nil, err
+	}
+	decoded = decoded[:n]
+	// The version byte and the null flag of the id precede it.
+	if len(decoded) < httpRequestIDLength+2 || decoded[1] != 0 {
+		return nil, newError(E0405ReadValueInvalidNullInputError)
+	}
+	copy(decoded[2:], requestID)
+	return decoded, nil
+}
+
+// errorResponse builds a response with the given status for the request, in the format of the connection.
+func (transporter *httpTransporter) errorResponse(requestID []byte, statusCode int, message string) []byte {
+	if transporter.connSettings.serializerType == GraphSONV3 {
+		return graphSONErrorResponse(requestID, statusCode, message)
+	}
+	return httpErrorResponse(requestID, statusCode, message)
+}
+
+// httpErrorResponse builds a GraphBinary response with the given status for the request.
+func httpErrorResponse(requestID []byte, statusCode int, message string) []byte {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte(versionByte)
+	buffer.WriteByte(0)
+	buffer.Write(requestID)
+	_ = binary.Write(&buffer, binary.BigEndian, uint32(statusCode))
+	buffer.WriteByte(0)
+	_ = binary.Write(&buffer, binary.BigEndian, uint32(len(message)))
+	buffer.WriteString(message)
+	// Empty status attributes and result meta.
+	_ = binary.Write(&buffer, binary.BigEndian, uint32(0))
+	_ = binary.Write(&buffer, binary.BigEndian, uint32(0))
+	buffer.WriteByte(byte(nullType))
+	buffer.WriteByte(valueFlagNull)
+	return buffer.Bytes()
+}
+
+// graphSONErrorResponse builds a GraphSON response with the given status for the request.
+func graphSONErrorResponse(requestID []byte, statusCode int, message string) []byte {
+	id, _ := uuid.FromBytes(requestID)
+	response, _ := json.Marshal(map[string]interface{}{
+		"requestId": id.String(),
+		"status":    map[string]interface{}{"code": statusCode, "message": message, "attributes": map[string]interface{}{}},
+		"result":    map[string]interface{}{"data": nil, "meta": map[string]interface{}{}},
+	})
+	return response
+}
+
+func (transporter *httpTransporter) Read() ([]byte, error) {
+	if err := transporter.Connect(); err != nil {
+		return nil, err
+	}
+	select {
+	case response := <-transporter.responses:
+		return response, nil
+	case <-transporter.ctx.Done():
+		return nil, newError(E0802HttpTransporterClosedError)
+	}
+}
+
+func (transporter *httpTransporter) Close() error {
+	transporter.mutex.Lock()
+	if transporter.isClosed {
+		transporter.mutex.Unlock()
+		return nil
+	}
+	transporter.isClosed = true
+	if transporter.cancel != nil {
+		transporter.cancel()
+	}
+	transporter.mutex.Unlock()
+
+	transporter.wg.Wait()
+	if transporter.client != nil {
+		transporter.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (transporter *httpTransporter) IsClosed() bool {
+	transporter.mutex.Lock()
+	defer transporter.mutex.Unlock()
+	return transporter.isClosed
+}
+
+func (transporter *httpTransporter) getAuthInfo() AuthInfoProvider {
+	if transporter.connSettings.authInfo == nil {
+		return NoopAuthInfo
+	}
+	return transporter.connSettings.authInfo
+}
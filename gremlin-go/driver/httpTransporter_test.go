@@ -0,0 +1,347 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// httpEndpointRequest is a request as it is read by the HTTP endpoint of Gremlin Server.
+type httpEndpointRequest struct {
+	header   http.Header
+	script   string
+	bindings map[string]interface{}
+	aliases  map[string]interface{}
+}
+
+// newHTTPEndpoint starts a server which follows the contract of the HTTP endpoint of Gremlin Server: the body is a JSON
+// object of the script and its bindings and aliases, errors are reported as JSON and results are serialized in the
+// format of the Accept header, GraphBinary being encoded in base64, with a random request id.
+func newHTTPEndpoint(t *testing.T, eval func(request *httpEndpointRequest) ([]interface{}, error)) *httptest.Server {
+	sendError := func(w http.ResponseWriter, statusCode int, message string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": message})
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			sendError(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+			return
+		}
+		var body map[string]interface{}
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			sendError(w, http.StatusBadRequest, "body could not be parsed")
+			return
+		}
+		request := &httpEndpointRequest{header: r.Header.Clone()}
+		script, ok := body["gremlin"].(string)
+		if !ok {
+			sendError(w, http.StatusBadRequest, "no gremlin script supplied")
+			return
+		}
+		request.script = script
+		if bindings, ok := body["bindings"]; ok {
+			if request.bindings, ok = bindings.(map[string]interface{}); !ok {
+				sendError(w, http.StatusBadRequest, "bindings must be a Map")
+				return
+			}
+		}
+		if aliases, ok := body["aliases"]; ok {
+			if request.aliases, ok = aliases.(map[string]interface{}); !ok {
+				sendError(w, http.StatusBadRequest, "aliases must be a Map")
+				return
+			}
+		}
+		accept := r.Header.Get("Accept")
+		if accept != graphBinaryMimeType && accept != graphSONV3MimeType {
+			sendError(w, http.StatusBadRequest, "no serializer for requested Accept header: "+accept)
+			return
+		}
+
+		results, err := eval(request)
+		if err != nil {
+			sendError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", accept)
+		responseID := uuid.New()
+		if accept == graphSONV3MimeType {
+			serializer := &graphSONTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+			data, err := serializer.write(results)
+			assert.Nil(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"requestId": responseID.String(),
+				"status":    map[string]interface{}{"message": "", "code": 200, "attributes": typedGraphSON("g:Map", []interface{}{})},
+				"result":    map[string]interface{}{"data": data, "meta": typedGraphSON("g:Map", []interface{}{})},
+			})
+			return
+		}
+		_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(graphBinaryTestResponse(t, responseID, results))))
+	}))
+}
+
+// graphBinaryTestResponse builds a successful GraphBinary response with the given id and results.
+func graphBinaryTestResponse(t *testing.T, responseID uuid.UUID, results []interface{}) []byte {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte(versionByte)
+	buffer.WriteByte(0)
+	buffer.Write(responseID[:])
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, uint32(http.StatusOK)))
+	buffer.WriteByte(valueFlagNull)
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, uint32(0)))
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, uint32(0)))
+	serializer := graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Error, language.English)}
+	_, err := serializer.write(results, &buffer)
+	assert.Nil(t, err)
+	return buffer.Bytes()
+}
+
+func newHTTPTestClient(t *testing.T, url string, configurations ...func(settings *ClientSettings)) *Client {
+	client, err := NewClient(url, append([]func(settings *ClientSettings){func(settings *ClientSettings) {
+		settings.TransporterType = HTTP
+		settings.LogVerbosity = Error
+	}}, configurations...)...)
+	assert.Nil(t, err)
+	return client
+}
+
+func TestHTTPTransporter(t *testing.T) {
+	t.Run("Test script round trip", func(t *testing.T) {
+		var lock sync.Mutex
+		var received *httpEndpointRequest
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			received = request
+			return []interface{}{int64(3)}, nil
+		})
+		defer server.Close()
+
+		client := newHTTPTestClient(t, server.URL, func(settings *ClientSettings) {
+			settings.AuthInfo = BasicAuthInfo("user", "pass")
+		})
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, int64(3), results[0].GetInterface())
+
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, "g.V().count()", received.script)
+		assert.Nil(t, received.bindings)
+		assert.Equal(t, map[string]interface{}{"g": "g"}, received.aliases)
+		assert.Equal(t, "application/json", received.header.Get("Content-Type"))
+		assert.Equal(t, graphBinaryMimeType, received.header.Get("Accept"))
+		assert.True(t, strings.HasPrefix(received.header.Get("Authorization"), "Basic "))
+		assert.Equal(t, userAgent, received.header.Get(userAgentHeader))
+	})
+
+	t.Run("Test bindings are sent as JSON", func(t *testing.T) {
+		var lock sync.Mutex
+		var bindings map[string]interface{}
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			bindings = request.bindings
+			return []interface{}{"ok"}, nil
+		})
+		defer server.Close()
+
+		client := newHTTPTestClient(t, server.URL)
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.V(x).has('name',within(names))", map[string]interface{}{
+			"x":     int32(1),
+			"names": []interface{}{"marko", "josh"},
+			"props": map[interface{}]interface{}{"weight": 0.5},
+		})
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.Nil(t, err)
+		lock.Lock()
+		assert.Equal(t, map[string]interface{}{
+			"x":     json.Number("1"),
+			"names": []interface{}{"marko", "josh"},
+			"props": map[string]interface{}{"weight": json.Number("0.5")},
+		}, bindings)
+		lock.Unlock()
+
+		_, err = client.Submit("g.V(x)", map[string]interface{}{"x": uuid.New()})
+		assert.True(t, isSameErrorCode(newError(E0804HttpTransporterBindingValueError), err))
+	})
+
+	t.Run("Test traversal is translated to a script", func(t *testing.T) {
+		var lock sync.Mutex
+		var scripts []string
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			scripts = append(scripts, request.script)
+			return []interface{}{int32(29), int32(27)}, nil
+		})
+		defer server.Close()
+
+		remote, err := NewDriverRemoteConnection(server.URL, func(settings *DriverRemoteConnectionSettings) {
+			settings.TransporterType = HTTP
+			settings.LogVerbosity = Error
+		})
+		assert.Nil(t, err)
+		defer remote.Close()
+		g := Traversal_().WithRemote(remote)
+
+		results, err := g.V().HasLabel("person").Values("age").Limit(2).ToList()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, int32(29), results[0].GetInterface())
+		lock.Lock()
+		assert.Equal(t, []string{"g.V().hasLabel('person').values('age').limit(2L)"}, scripts)
+		lock.Unlock()
+
+		session, err := remote.CreateSession()
+		assert.Nil(t, err)
+		_, err = Traversal_().WithRemote(session).V().ToList()
+		assert.True(t, isSameErrorCode(newError(E0803HttpTransporterUnsupportedRequestError), err))
+	})
+
+	t.Run("Test websocket url is mapped to http", func(t *testing.T) {
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			return []interface{}{"ok"}, nil
+		})
+		defer server.Close()
+
+		client := newHTTPTestClient(t, strings.Replace(server.URL, "http://", "ws://", 1)+"/gremlin")
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.inject('ok')")
+		assert.Nil(t, err)
+		result, ok, err := resultSet.One()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "ok", result.GetString())
+	})
+
+	t.Run("Test error response fails only its ResultSet", func(t *testing.T) {
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			if request.script == "fail" {
+				return nil, errors.New("script failed")
+			}
+			return []interface{}{int32(1)}, nil
+		})
+		defer server.Close()
+
+		client := newHTTPTestClient(t, server.URL)
+		defer client.Close()
+
+		resultSet, err := client.Submit("fail")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		var responseError *ResponseError
+		assert.True(t, errors.As(err, &responseError))
+		assert.Equal(t, uint16(http.StatusInternalServerError), responseError.StatusCode)
+		assert.Equal(t, "script failed", responseError.StatusMessage)
+
+		resultSet, err = client.Submit("g.inject(1)")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), results[0].GetInterface())
+	})
+
+	t.Run("Test GraphSON round trip", func(t *testing.T) {
+		server := newHTTPEndpoint(t, func(request *httpEndpointRequest) ([]interface{}, error) {
+			if request.script == "fail" {
+				return nil, fmt.Errorf("script failed")
+			}
+			return []interface{}{int64(3)}, nil
+		})
+		defer server.Close()
+
+		client := newHTTPTestClient(t, server.URL, func(settings *ClientSettings) {
+			settings.Serializer = GraphSONV3
+		})
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, int64(3), results[0].GetInterface())
+
+		resultSet, err = client.Submit("fail")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		var responseError *ResponseError
+		assert.True(t, errors.As(err, &responseError))
+		assert.Equal(t, uint16(http.StatusInternalServerError), responseError.StatusCode)
+		assert.Equal(t, "script failed", responseError.StatusMessage)
+	})
+
+	t.Run("Test unreachable server fails request", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		client := newHTTPTestClient(t, url)
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.V()")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Test write after close", func(t *testing.T) {
+		transporter := &httpTransporter{
+			url:          "http://localhost:8182/gremlin",
+			logHandler:   newLogHandler(&defaultLogger{}, Error, language.English),
+			connSettings: newDefaultConnectionSettings(),
+			responses:    make(chan []byte, 1),
+			wg:           &sync.WaitGroup{},
+		}
+		assert.Nil(t, transporter.Connect())
+		assert.False(t, transporter.IsClosed())
+		assert.Nil(t, transporter.Close())
+		assert.True(t, transporter.IsClosed())
+		assert.NotNil(t, transporter.Write([]byte{}))
+		_, err := transporter.Read()
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Request is an outgoing request as seen by an Interceptor. Its fields may be changed before it is passed on, for
+// example to add arguments such as a default "evaluationTimeout".
+type Request struct {
+	RequestID uuid.UUID
+	Op        string
+	Processor string
+	Args      map[string]interface{}
+}
+
+// Invoker sends a Request to the server and returns the ResultSet receiving its results.
+type Invoker func(ctx context.Context, request *Request) (ResultSet, error)
+
+// Interceptor wraps the sending of every request made by a Client or DriverRemoteConnection. It may inspect or change
+// the Request before calling next, which sends it through the remaining interceptors to the server, and may observe
+// the returned ResultSet. ResultSet.OnComplete can be used to observe the status attributes, the error and the latency
+// of the request once the server has finished responding. Interceptors are called in the order in which they are
+// configured, so the first one is the outermost.
+type Interceptor func(ctx context.Context, request *Request, next Invoker) (ResultSet, error)
+
+// chainInterceptors returns an Invoker which calls the interceptors in order before invoker.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, request *Request) (ResultSet, error) {
+			return interceptor(ctx, request, next)
+		}
+	}
+	return invoker
+}
+
+// exported returns the Request through which interceptors see req.
+func (req *request) exported() *Request {
+	return &Request{
+		RequestID: req.requestID,
+		Op:        req.op,
+		Processor: req.processor,
+		Args:      req.args,
+	}
+}
+
+// internal returns the request which is sent to the server for r.
+func (r *Request) internal() request {
+	return request{
+		requestID: r.RequestID,
+		op:        r.Op,
+		processor: r.Processor,
+		args:      r.Args,
+	}
+}
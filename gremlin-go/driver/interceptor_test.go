@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingPool is a connectionPool which records the requests written to it and answers them with respond.
+type recordingPool struct {
+	scriptedPool
+	requests []*request
+}
+
+func (pool *recordingPool) write(request *request) (ResultSet, error) {
+	pool.lock.Lock()
+	pool.requests = append(pool.requests, request)
+	pool.lock.Unlock()
+	return pool.scriptedPool.write(request)
+}
+
+func respondWithAttributes(data interface{}, attributes map[string]interface{}) func(ResultSet) error {
+	return func(resultSet ResultSet) error {
+		resultSet.addResult(&Result{data})
+		resultSet.setStatusAttributes(attributes)
+		resultSet.Close()
+		return nil
+	}
+}
+
+func newInterceptorTestClient(interceptors []Interceptor, outcomes ...func(ResultSet) error) (*Client, *recordingPool) {
+	pool := &recordingPool{scriptedPool: scriptedPool{outcomes: outcomes}}
+	client, _ := newRetryTestClient(fastRetryPolicy())
+	client.connections = pool
+	client.interceptors = interceptors
+	return client, pool
+}
+
+func TestInterceptor(t *testing.T) {
+	t.Run("Test interceptors are called in order", func(t *testing.T) {
+		var calls []string
+		record := func(name string) Interceptor {
+			return func(ctx context.Context, request *Request, next Invoker) (ResultSet, error) {
+				calls = append(calls, name+" before")
+				resultSet, err := next(ctx, request)
+				calls = append(calls, name+" after")
+				return resultSet, err
+			}
+		}
+		client, _ := newInterceptorTestClient([]Interceptor{record("first"), record("second")}, respondWith(int64(1)))
+		_, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+	})
+
+	t.Run("Test interceptor sees and changes request", func(t *testing.T) {
+		var seen Request
+		defaultTimeout := func(ctx context.Context, request *Request, next Invoker) (ResultSet, error) {
+			seen = *request
+			if _, ok := request.Args["evaluationTimeout"]; !ok {
+				request.Args["evaluationTimeout"] = 1000
+			}
+			request.Args["tenant"] = "acme"
+			return next(ctx, request)
+		}
+		client, pool := newInterceptorTestClient([]Interceptor{defaultTimeout}, respondWith(int64(1)), respondWith(int64(1)))
+
+		_, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		assert.Equal(t, stringOp, seen.Op)
+		assert.Equal(t, stringProcessor, seen.Processor)
+		assert.Equal(t, "g.V().count()", seen.Args["gremlin"])
+		assert.Equal(t, pool.requests[0].requestID, seen.RequestID)
+		assert.Equal(t, 1000, pool.requests[0].args["evaluationTimeout"])
+		assert.Equal(t, "acme", pool.requests[0].args["tenant"])
+
+		g := Traversal_().WithRemote(&DriverRemoteConnection{client: client})
+		_, err = g.With("evaluationTimeout", 500).V().Count().Next()
+		assert.Nil(t, err)
+		assert.Equal(t, bytecodeOp, seen.Op)
+		assert.Equal(t, bytecodeProcessor, seen.Processor)
+		assert.Equal(t, 500, pool.requests[1].args["evaluationTimeout"])
+	})
+
+	t.Run("Test interceptor observes completion", func(t *testing.T) {
+		var attributes map[string]interface{}
+		var latency time.Duration
+		var completionErr error
+		observe := func(ctx context.Context, request *Request, next Invoker) (ResultSet, error) {
+			start := time.Now()
+			resultSet, err := next(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			resultSet.OnComplete(func() {
+				latency = time.Since(start)
+				attributes = resultSet.GetStatusAttributes()
+				completionErr = resultSet.GetError()
+			})
+			return resultSet, nil
+		}
+		overloaded := &ResponseError{StatusCode: 596}
+		client, _ := newInterceptorTestClient([]Interceptor{observe},
+			respondWithAttributes(int64(1), map[string]interface{}{"host": "localhost"}), failWith(overloaded))
+
+		resultSet, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"host": "localhost"}, attributes)
+		assert.True(t, latency > 0)
+		assert.Nil(t, completionErr)
+
+		resultSet, err = client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		_, _ = resultSet.All()
+		assert.True(t, errors.Is(completionErr, overloaded))
+	})
+
+	t.Run("Test interceptor can fail request", func(t *testing.T) {
+		denied := errors.New("denied")
+		deny := func(ctx context.Context, request *Request, next Invoker) (ResultSet, error) {
+			return nil, denied
+		}
+		client, pool := newInterceptorTestClient([]Interceptor{deny}, respondWith(int64(1)))
+		_, err := client.Submit("g.V().count()")
+		assert.Equal(t, denied, err)
+		assert.Empty(t, pool.requests)
+	})
+
+	t.Run("Test interceptors wrap each retry", func(t *testing.T) {
+		var requestIDs []string
+		record := func(ctx context.Context, request *Request, next Invoker) (ResultSet, error) {
+			requestIDs = append(requestIDs, request.RequestID.String())
+			return next(ctx, request)
+		}
+		client, _ := newInterceptorTestClient([]Interceptor{record}, failWith(&ResponseError{StatusCode: 596}), respondWith(int64(1)))
+		_, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		assert.Len(t, requestIDs, 2)
+		assert.NotEqual(t, requestIDs[0], requestIDs[1])
+	})
+}
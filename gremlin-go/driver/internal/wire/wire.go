@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package wire gives the stand-in server of the gremlintest package and the performance suite access to the
+// GraphBinary serialization of the driver, without making the messages exchanged with Gremlin Server part of the API
+// of the gremlingo package.
+package wire
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// RequestMessage is a request as it is received by Gremlin Server.
+type RequestMessage struct {
+	RequestID uuid.UUID
+	Op        string
+	Processor string
+	Args      map[string]interface{}
+}
+
+// ResponseMessage is a response as it is sent by Gremlin Server.
+type ResponseMessage struct {
+	RequestID        uuid.UUID
+	StatusCode       uint16
+	StatusMessage    string
+	StatusAttributes map[string]interface{}
+	Meta             map[string]interface{}
+	Data             interface{}
+}
+
+// The serialization functions are implemented by the gremlingo package, which sets them when it is initialized.
+var (
+	// ReadGraphBinaryRequest deserializes a request message written in GraphBinary by a Client or a
+	// DriverRemoteConnection.
+	ReadGraphBinaryRequest func(message []byte) (*RequestMessage, error)
+	// WriteGraphBinaryResponse serializes a response message into GraphBinary, as Gremlin Server does.
+	WriteGraphBinaryResponse func(response *ResponseMessage) ([]byte, error)
+	// ReadGraphBinaryResponse deserializes a response message written in GraphBinary, as the driver does. The message
+	// is decoded while it is read from reader.
+	ReadGraphBinaryResponse func(reader io.Reader) (*ResponseMessage, error)
+	// MarshalGraphBinary serializes a value into fully qualified GraphBinary.
+	MarshalGraphBinary func(value interface{}) ([]byte, error)
+	// UnmarshalGraphBinary deserializes a fully qualified GraphBinary value, which may be one that only clients
+	// write, such as Bytecode.
+	UnmarshalGraphBinary func(data []byte) (interface{}, error)
+)
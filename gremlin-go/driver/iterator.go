@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+// Seq is an iterator over values along with errors, which has the signature of iter.Seq2[T, error]. With Go 1.23 or
+// later it can be ranged over:
+//
+//	for result, err := range g.V().All() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(result)
+//	}
+//
+// With earlier versions, it is called with the function which is otherwise the body of the loop, returning false to
+// stop the iteration.
+type Seq[T any] func(yield func(T, error) bool)
+
+// AllAs returns an iterator over the results of the traversal as T, as converted by As. The iteration stops with the
+// first result which cannot be converted.
+func AllAs[T any](traversal *GraphTraversal) Seq[T] {
+	return seqAs[T](traversal.All())
+}
+
+// IterAs returns an iterator over the remaining results of the ResultSet as T, as converted by As. The iteration stops
+// with the first result which cannot be converted.
+func IterAs[T any](resultSet ResultSet) Seq[T] {
+	return seqAs[T](resultSet.Iter())
+}
+
+func seqAs[T any](results Seq[*Result]) Seq[T] {
+	return func(yield func(T, error) bool) {
+		results(func(result *Result, err error) bool {
+			var value T
+			if err == nil {
+				value, err = As[T](result)
+			}
+			// The iteration stops after an error, whether or not yield asks it to.
+			return yield(value, err) && err == nil
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collect returns the values and the errors yielded by seq.
+func collect[T any](seq Seq[T]) ([]T, []error) {
+	var values []T
+	var errs []error
+	seq(func(value T, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			values = append(values, value)
+		}
+		return true
+	})
+	return values, errs
+}
+
+func TestIterator(t *testing.T) {
+	const mockID = "mockID"
+
+	t.Run("Test ResultSet Iter", func(t *testing.T) {
+		resultSet := newChannelResultSet(mockID, getSyncMap())
+		go func() {
+			resultSet.addResult(&Result{[]interface{}{int32(1), int32(2)}})
+			resultSet.addResult(&Result{[]interface{}{int32(3)}})
+			resultSet.Close()
+		}()
+		results, errs := collect(resultSet.Iter())
+		assert.Empty(t, errs)
+		assert.Equal(t, []*Result{{int32(1)}, {int32(2)}, {int32(3)}}, results)
+	})
+
+	t.Run("Test ResultSet Iter error", func(t *testing.T) {
+		resultSet := newChannelResultSet(mockID, getSyncMap())
+		go func() {
+			resultSet.addResult(&Result{[]interface{}{int32(1)}})
+			resultSet.setError(newError(E0502ResponseHandlerReadLoopError, "failed", 500))
+			resultSet.Close()
+		}()
+		results, errs := collect(resultSet.Iter())
+		assert.Equal(t, []*Result{{int32(1)}}, results)
+		assert.Len(t, errs, 1)
+		assert.True(t, isSameErrorCode(newError(E0502ResponseHandlerReadLoopError), errs[0]))
+	})
+
+	t.Run("Test ResultSet Iter stopped early", func(t *testing.T) {
+		container := getSyncMap()
+		resultSet := newChannelResultSetCapacity(mockID, container, 1)
+		container.store(mockID, resultSet)
+		added := make(chan struct{})
+		go func() {
+			resultSet.addResult(&Result{[]interface{}{int32(1), int32(2), int32(3)}})
+			close(added)
+		}()
+		count := 0
+		resultSet.Iter()(func(result *Result, err error) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+		// Cancelling the ResultSet releases addResult, which is blocked on the full channel.
+		<-added
+		assert.Nil(t, resultSet.GetError())
+		assert.Nil(t, container.load(mockID))
+	})
+
+	t.Run("Test ResultSet IterContext cancelled", func(t *testing.T) {
+		resultSet := newChannelResultSet(mockID, getSyncMap())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, errs := collect(resultSet.IterContext(ctx))
+		assert.Equal(t, []error{context.Canceled}, errs)
+		assert.Equal(t, context.Canceled, resultSet.GetError())
+	})
+
+	t.Run("Test IterAs", func(t *testing.T) {
+		resultSet := newChannelResultSet(mockID, getSyncMap())
+		resultSet.addResult(&Result{[]interface{}{int32(1), int64(2), "three", int32(4)}})
+		resultSet.Close()
+		values, errs := collect(IterAs[int64](resultSet))
+		assert.Equal(t, []int64{1, 2}, values)
+		assert.Len(t, errs, 1)
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), errs[0]))
+	})
+
+	t.Run("Test Traversal All anonymous traversal failure", func(t *testing.T) {
+		results, errs := collect(T__.V().All())
+		assert.Empty(t, results)
+		assert.Len(t, errs, 1)
+		assert.True(t, isSameErrorCode(newError(E0901ToListAnonTraversalError), errs[0]))
+
+		values, errs := collect(AllAs[string](T__.V().Values("name")))
+		assert.Empty(t, values)
+		assert.Len(t, errs, 1)
+	})
+}
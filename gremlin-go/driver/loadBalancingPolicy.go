@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// Host describes a Gremlin Server endpoint of a cluster to a LoadBalancingPolicy.
+type Host struct {
+	URL string
+	// InFlight is the number of requests that are waiting for a response from the host.
+	InFlight int
+}
+
+// LoadBalancingPolicy decides which host of a cluster serves a request.
+type LoadBalancingPolicy interface {
+	// Select returns the hosts that should be tried for the next request, in order of preference. Only hosts that are
+	// up are passed, in the order in which they were configured. The request fails over to the next host if writing to
+	// a host fails.
+	Select(hosts []Host) []Host
+}
+
+type roundRobinPolicy struct {
+	next uint64
+}
+
+// RoundRobinPolicy creates a LoadBalancingPolicy that spreads requests evenly by starting at the next host for every
+// request.
+func RoundRobinPolicy() LoadBalancingPolicy {
+	return &roundRobinPolicy{}
+}
+
+// Select rotates hosts so that a different host comes first for every request.
+func (policy *roundRobinPolicy) Select(hosts []Host) []Host {
+	if len(hosts) == 0 {
+		return hosts
+	}
+	start := int((atomic.AddUint64(&policy.next, 1) - 1) % uint64(len(hosts)))
+	return append(append(make([]Host, 0, len(hosts)), hosts[start:]...), hosts[:start]...)
+}
+
+type leastInFlightPolicy struct{}
+
+// LeastInFlightPolicy creates a LoadBalancingPolicy that prefers the host with the fewest requests waiting for a
+// response.
+func LeastInFlightPolicy() LoadBalancingPolicy {
+	return &leastInFlightPolicy{}
+}
+
+// Select orders hosts by the number of requests in flight, keeping the configured order for ties.
+func (policy *leastInFlightPolicy) Select(hosts []Host) []Host {
+	ordered := append(make([]Host, 0, len(hosts)), hosts...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].InFlight < ordered[j].InFlight
+	})
+	return ordered
+}
+
+type primaryWithFallbackPolicy struct{}
+
+// PrimaryWithFallbackPolicy creates a LoadBalancingPolicy that sends every request to the first configured host and
+// only falls back to the other hosts, in the configured order, while it is down.
+func PrimaryWithFallbackPolicy() LoadBalancingPolicy {
+	return &primaryWithFallbackPolicy{}
+}
+
+// Select keeps hosts in the configured order.
+func (policy *primaryWithFallbackPolicy) Select(hosts []Host) []Host {
+	return hosts
+}
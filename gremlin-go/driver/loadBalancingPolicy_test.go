@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getHostURLs(hosts []Host) []string {
+	urls := make([]string, len(hosts))
+	for i, host := range hosts {
+		urls[i] = host.URL
+	}
+	return urls
+}
+
+func TestLoadBalancingPolicy(t *testing.T) {
+	hosts := []Host{{URL: "a", InFlight: 3}, {URL: "b", InFlight: 1}, {URL: "c", InFlight: 1}}
+
+	t.Run("Test RoundRobinPolicy", func(t *testing.T) {
+		policy := RoundRobinPolicy()
+		assert.Equal(t, []string{"a", "b", "c"}, getHostURLs(policy.Select(hosts)))
+		assert.Equal(t, []string{"b", "c", "a"}, getHostURLs(policy.Select(hosts)))
+		assert.Equal(t, []string{"c", "a", "b"}, getHostURLs(policy.Select(hosts)))
+		assert.Equal(t, []string{"a", "b", "c"}, getHostURLs(policy.Select(hosts)))
+		assert.Empty(t, policy.Select(nil))
+	})
+
+	t.Run("Test LeastInFlightPolicy", func(t *testing.T) {
+		policy := LeastInFlightPolicy()
+		assert.Equal(t, []string{"b", "c", "a"}, getHostURLs(policy.Select(hosts)))
+		// The passed hosts are not reordered.
+		assert.Equal(t, []string{"a", "b", "c"}, getHostURLs(hosts))
+	})
+
+	t.Run("Test PrimaryWithFallbackPolicy", func(t *testing.T) {
+		policy := PrimaryWithFallbackPolicy()
+		assert.Equal(t, []string{"a", "b", "c"}, getHostURLs(policy.Select(hosts)))
+		assert.Equal(t, []string{"b", "c"}, getHostURLs(policy.Select(hosts[1:])))
+	})
+}
@@ -127,4 +127,12 @@ const (
 	poolNewConnectionError       errorKey = "POOL_NEW_CONNECTION_ERROR"
 	sessionDetected              errorKey = "SESSION_DETECTED"
 	poolInitialExceedsMaximum    errorKey = "POOL_INITIAL_EXCEEDS_MAXIMUM"
+	poolReconnected              errorKey = "POOL_RECONNECTED"
+	poolReconnectBackoff         errorKey = "POOL_RECONNECT_BACKOFF"
+	hostMarkedDown               errorKey = "HOST_MARKED_DOWN"
+	hostMarkedUp                 errorKey = "HOST_MARKED_UP"
+	hostStillDown                errorKey = "HOST_STILL_DOWN"
+	retryingRequest              errorKey = "RETRYING_REQUEST"
+	failedToReadResponse         errorKey = "FAILED_TO_READ_RESPONSE"
+	discardUnknownResponse       errorKey = "DISCARD_UNKNOWN_RESPONSE"
 )
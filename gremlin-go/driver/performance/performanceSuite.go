@@ -20,6 +20,7 @@ under the License.
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"log"
@@ -27,9 +28,11 @@ import (
 	"runtime"
 	"sort"
 	"strconv"
+	"testing"
 	"time"
 
 	"github.com/apache/tinkerpop/gremlin-go/v3/driver"
+	"github.com/apache/tinkerpop/gremlin-go/v3/driver/internal/wire"
 )
 
 type ResultSet = gremlingo.ResultSet
@@ -66,6 +69,16 @@ const fullTraversal = 500
 const retrieveOne = "retrieve one"
 const retrieveAll = "retrieve all"
 const connectionPooling = "connection pooling"
+const deserialization = "deserialization"
+
+// number of vertices in the response of the deserialization test
+const deserializationVertexCount = 1000
+
+// Allocations per response of the deserialization test with the decoder which GraphBinary responses were read with
+// before they were streamed, which decoded the message after it was read into a []byte as a whole. Measured with
+// go1.20 on linux/amd64, not counting the copy of the message itself.
+const baselineDeserializationAllocs = 58515
+const baselineDeserializationBytes = 1172537
 
 // placeholder variable to assign result value to
 var retrievedRes interface{}
@@ -189,6 +202,65 @@ func executeAndRetrieveAll(t *GraphTraversal) (*performanceResults, error) {
 	}, nil
 }
 
+// generateValueMapResponse generates a GraphBinary response of the ValueMap(true) of vertices, as it is sent by the
+// server.
+func generateValueMapResponse(vertexCount int) ([]byte, error) {
+	var data []interface{}
+	for i := 0; i < vertexCount; i++ {
+		data = append(data, map[interface{}]interface{}{
+			gremlingo.T.Id:    int64(i),
+			gremlingo.T.Label: "song",
+			"name":            []interface{}{"song " + strconv.Itoa(i)},
+			"songType":        []interface{}{"original"},
+			"performances":    []interface{}{int32(i)},
+		})
+	}
+	return wire.WriteGraphBinaryResponse(&wire.ResponseMessage{StatusCode: 200, Data: data})
+}
+
+// runDeserialization benchmarks decoding a GraphBinary response while it is read, and reports the time and the
+// allocations it takes per response, along with the reduction of allocations compared to the decoder used before
+// responses were streamed. It does not need a server.
+func runDeserialization() {
+	message, err := generateValueMapResponse(deserializationVertexCount)
+	if err != nil {
+		log.Panic("Error generating response:", err)
+		return
+	}
+	fmt.Printf("Deserializing a response of %d bytes holding the value maps of %d vertices.\n", len(message),
+		deserializationVertexCount)
+	result := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			response, err := wire.ReadGraphBinaryResponse(bytes.NewReader(message))
+			if err != nil {
+				b.Fatal(err)
+			}
+			retrievedRes = response.Data
+		}
+	})
+	if result.N == 0 {
+		log.Println("Error deserializing the response")
+		return
+	}
+	fmt.Println("================================================")
+	fmt.Println("Results for", deserialization)
+	fmt.Printf("\tDESERIALIZATION STATS:\t%d runs\t%d ns/op\t%d B/op\t%d allocs/op\n", result.N, result.NsPerOp(),
+		result.AllocedBytesPerOp(), result.AllocsPerOp())
+	fmt.Printf("\tBASELINE STATS:\t%d B/op\t%d allocs/op\n", baselineDeserializationBytes,
+		baselineDeserializationAllocs)
+	fmt.Printf("\tSTREAMING REDUCTION:\t%.1f%% allocs\t%.1f%% B\n",
+		reduction(baselineDeserializationAllocs, uint64(result.AllocsPerOp())),
+		reduction(baselineDeserializationBytes, uint64(result.AllocedBytesPerOp())))
+}
+
+func reduction(before, after uint64) float64 {
+	if before == 0 {
+		return 0
+	}
+	return 100 * (float64(before) - float64(after)) / float64(before)
+}
+
 func run(g *GraphTraversalSource, runs int, runLevel string, memProfile bool) {
 	if runs < 6 {
 		log.Panic("each test must be run greater than 6 times")
@@ -404,6 +476,10 @@ A projection query using ValueMap is executed for each test, g.V().Project(1).By
 the number of projection scaled up depending on test suite run level. Smoke tests runs the projection query with 10 
 projections, and full test runs the smoke test plus the query with 500 projections. Connection pooling test runs the 
 query with 10 projections, executed 250 times per run with a default set of connection pool sizes (2, 4, 8). 
+Deserialization test does not connect to the server. It benchmarks deserializing a generated response holding the 
+value maps of 1000 vertices while it is read, and reports the time, bytes and allocations per response, along with 
+the reduction of allocations compared to a recorded baseline of the decoder used before responses were streamed. It 
+is run as many times as the Go benchmark framework needs for a stable measurement, regardless of runCount.
 
 Each test is run 55 times by default, with the slowest 5 runs removed, and execution time is taken for retrieving a 
 single result with Next(), and retrieving all results with ToList(). 
@@ -424,7 +500,7 @@ func main() {
 	portPtr := flag.Int("port", Port, "Server port, the default is 45940.")
 	runCount := flag.Int("runCount", suiteRunCount, "The number of times to run each test, the default is 55, minimum is 6.")
 	memProfile := flag.Bool("memProfile", false, "Enables memory profiling.")
-	runLevel := flag.String("runLevel", "smoke", "The test suite to run: smoke, full, or pooling, the default is smoke")
+	runLevel := flag.String("runLevel", "smoke", "The test suite to run: smoke, full, pooling, or deserialization, the default is smoke")
 
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), usage)
@@ -432,6 +508,12 @@ func main() {
 	}
 
 	flag.Parse()
+	if *runLevel == "deserialization" {
+		fmt.Println("===== RUNNING DESERIALIZATION PERFORMANCE TEST =====")
+		runDeserialization()
+		fmt.Println("================================================")
+		return
+	}
 	fmt.Println("===== RUNNING PERFORMANCE TEST SUITE ON THE GRATEFUL GRAPH =====")
 	if *runLevel == "pooling" {
 		for _, size := range poolSize {
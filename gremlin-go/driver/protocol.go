@@ -20,9 +20,13 @@ under the License.
 package gremlingo
 
 import (
+	"bytes"
 	"encoding/base64"
+	"io"
 	"net/http"
 	"sync"
+
+	"github.com/google/uuid"
 )
 
 // protocol handles invoking serialization and deserialization, as well as handling the lifecycle of raw data passed to
@@ -33,18 +37,17 @@ type protocol interface {
 	close(wait bool) error
 }
 
-const authenticationFailed = uint16(151)
-
 type protocolBase struct {
 	protocol
 
-	transporter transporter
+	transporter Transporter
 }
 
 type gremlinServerWSProtocol struct {
 	*protocolBase
 
 	serializer serializer
+	authInfo   AuthInfoProvider
 	logHandler *logHandler
 	closed     bool
 	mutex      sync.Mutex
@@ -56,7 +59,7 @@ func (protocol *gremlinServerWSProtocol) readLoop(resultSets *synchronizedMap, e
 
 	for {
 		// Read from transport layer. If the channel is closed, this will error out and exit.
-		msg, err := protocol.transporter.Read()
+		msg, err := protocol.nextMessage()
 		protocol.mutex.Lock()
 		if protocol.closed {
 			protocol.mutex.Unlock()
@@ -67,13 +70,18 @@ func (protocol *gremlinServerWSProtocol) readLoop(resultSets *synchronizedMap, e
 			// Ignore error here, we already got an error on read, cannot do anything with this.
 			_ = protocol.transporter.Close()
 			protocol.logHandler.logf(Error, readLoopError, err.Error())
-			readErrorHandler(resultSets, errorCallback, err, protocol.logHandler)
+			readErrorHandler(resultSets, errorCallback, &connectionError{err}, protocol.logHandler)
 			return
 		}
 
 		// Deserialize message and unpack.
 		resp, err := protocol.serializer.deserializeMessage(msg)
 		if err != nil {
+			if resp.responseID != uuid.Nil {
+				// The request the response belongs to is known, so only that request is failed.
+				protocol.failResponse(resultSets, resp, err)
+				continue
+			}
 			protocol.logHandler.logf(Error, logErrorGeneric, "gremlinServerWSProtocol.readLoop()", err.Error())
 			readErrorHandler(resultSets, errorCallback, err, protocol.logHandler)
 			return
@@ -87,6 +95,32 @@ func (protocol *gremlinServerWSProtocol) readLoop(resultSets *synchronizedMap, e
 	}
 }
 
+// nextMessage returns the next message received by the transport layer. Messages are streamed if the Transporter
+// supports it, so that they are deserialized while they are received.
+func (protocol *gremlinServerWSProtocol) nextMessage() (io.Reader, error) {
+	if transporter, ok := protocol.transporter.(StreamingTransporter); ok {
+		return transporter.NextReader()
+	}
+	msg, err := protocol.transporter.Read()
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(msg), nil
+}
+
+// failResponse fails the ResultSet of a response that could not be deserialized. The ResultSet is cancelled rather than
+// closed, so that the remaining parts of a partial response are discarded when they arrive.
+func (protocol *gremlinServerWSProtocol) failResponse(resultSets *synchronizedMap, response response, err error) {
+	responseIDString := response.responseID.String()
+	protocol.logHandler.logf(Error, failedToReadResponse, responseIDString, err.Error())
+	if resultSet := resultSets.load(responseIDString); resultSet != nil {
+		resultSet.cancel(err)
+	}
+	if response.responseStatus.code != http.StatusPartialContent {
+		resultSets.discard(responseIDString, true)
+	}
+}
+
 // If there is an error, we need to close the ResultSets and then pass the error back.
 func readErrorHandler(resultSets *synchronizedMap, errorCallback func(), err error, log *logHandler) {
 	log.logf(Error, readLoopError, err.Error())
@@ -98,31 +132,38 @@ func (protocol *gremlinServerWSProtocol) responseHandler(resultSets *synchronize
 	responseID, statusCode, metadata, data := response.responseID, response.responseStatus.code,
 		response.responseResult.meta, response.responseResult.data
 	responseIDString := responseID.String()
-	if resultSets.load(responseIDString) == nil {
-		return newError(err0501ResponseHandlerResultSetNotCreatedError)
+	// The ResultSet is loaded once, as it can be removed from resultSets concurrently if its context is done.
+	resultSet := resultSets.load(responseIDString)
+	if resultSet == nil {
+		if resultSets.discard(responseIDString, statusCode != http.StatusPartialContent) {
+			// The request was cancelled while the server was still responding.
+			return nil
+		}
+		// Other requests on the connection are unaffected by a response that cannot be matched to a request.
+		protocol.logHandler.logf(Warning, discardUnknownResponse, responseIDString)
+		return nil
 	}
 	if aggregateTo, ok := metadata["aggregateTo"]; ok {
-		resultSets.load(responseIDString).setAggregateTo(aggregateTo.(string))
+		resultSet.setAggregateTo(aggregateTo.(string))
 	}
 
 	// Handle status codes appropriately. If status code is http.StatusPartialContent, we need to re-read data.
 	if statusCode == http.StatusNoContent {
-		resultSets.load(responseIDString).addResult(&Result{make([]interface{}, 0)})
-		resultSets.load(responseIDString).Close()
+		resultSet.addResult(&Result{make([]interface{}, 0)})
+		resultSet.Close()
 		protocol.logHandler.logf(Debug, readComplete, responseIDString)
 	} else if statusCode == http.StatusOK {
 		// Add data and status attributes to the ResultSet.
-		resultSets.load(responseIDString).addResult(&Result{data})
-		resultSets.load(responseIDString).setStatusAttributes(response.responseStatus.attributes)
-		resultSets.load(responseIDString).Close()
+		resultSet.addResult(&Result{data})
+		resultSet.setStatusAttributes(response.responseStatus.attributes)
+		resultSet.Close()
 		protocol.logHandler.logf(Debug, readComplete, responseIDString)
 	} else if statusCode == http.StatusPartialContent {
 		// Add data to the ResultSet.
-		resultSets.load(responseIDString).addResult(&Result{data})
-	} else if statusCode == http.StatusProxyAuthRequired || statusCode == authenticationFailed {
-		// http status code 151 is not defined here, but corresponds with 403, i.e. authentication has failed.
+		resultSet.addResult(&Result{data})
+	} else if statusCode == http.StatusProxyAuthRequired {
 		// Server has requested basic auth.
-		authInfo := protocol.transporter.getAuthInfo()
+		authInfo := protocol.authInfo
 		if ok, username, password := authInfo.GetBasicAuth(); ok {
 			authBytes := make([]byte, 0)
 			authBytes = append(authBytes, 0)
@@ -136,14 +177,15 @@ func (protocol *gremlinServerWSProtocol) responseHandler(resultSets *synchronize
 				return err
 			}
 		} else {
-			resultSets.load(responseIDString).Close()
-			return newError(err0503ResponseHandlerAuthError, response.responseStatus, response.responseResult)
+			resultSet.setError(newError(E0503ResponseHandlerAuthError, response.responseStatus, response.responseResult))
+			resultSet.Close()
 		}
 	} else {
-		newError := newError(err0502ResponseHandlerReadLoopError, response.responseStatus, statusCode)
-		resultSets.load(responseIDString).setError(newError)
-		resultSets.load(responseIDString).Close()
-		protocol.logHandler.logf(Error, logErrorGeneric, "gremlinServerWSProtocol.responseHandler()", newError.Error())
+		responseError := newResponseError(response)
+		resultSet.setError(responseError)
+		resultSet.setStatusAttributes(response.responseStatus.attributes)
+		resultSet.Close()
+		protocol.logHandler.logf(Error, logErrorGeneric, "gremlinServerWSProtocol.responseHandler()", responseError.Error())
 	}
 	return nil
 }
@@ -153,7 +195,10 @@ func (protocol *gremlinServerWSProtocol) write(request *request) error {
 	if err != nil {
 		return err
 	}
-	return protocol.transporter.Write(bytes)
+	if err := protocol.transporter.Write(bytes); err != nil {
+		return &connectionError{err}
+	}
+	return nil
 }
 
 func (protocol *gremlinServerWSProtocol) close(wait bool) error {
@@ -176,14 +221,30 @@ func (protocol *gremlinServerWSProtocol) close(wait bool) error {
 func newGremlinServerWSProtocol(handler *logHandler, transporterType TransporterType, url string, connSettings *connectionSettings, results *synchronizedMap,
 	errorCallback func()) (protocol, error) {
 	wg := &sync.WaitGroup{}
+	var serializer serializer
+	var err error
+	if transporterType == HTTP {
+		serializer, err = newHTTPSerializer(connSettings.serializerType, handler)
+	} else {
+		serializer, err = newSerializer(connSettings.serializerType, handler)
+	}
+	if err != nil {
+		return nil, err
+	}
 	transport, err := getTransportLayer(transporterType, url, connSettings, handler)
 	if err != nil {
 		return nil, err
 	}
 
+	var authInfo AuthInfoProvider = NoopAuthInfo
+	if connSettings.authInfo != nil {
+		authInfo = connSettings.authInfo
+	}
+
 	gremlinProtocol := &gremlinServerWSProtocol{
 		protocolBase: &protocolBase{transporter: transport},
-		serializer:   newGraphBinarySerializer(handler),
+		serializer:   serializer,
+		authInfo:     authInfo,
 		logHandler:   handler,
 		closed:       false,
 		mutex:        sync.Mutex{},
@@ -191,7 +252,7 @@ func newGremlinServerWSProtocol(handler *logHandler, transporterType Transporter
 	}
 	err = gremlinProtocol.transporter.Connect()
 	if err != nil {
-		return nil, err
+		return nil, &connectionError{err}
 	}
 	wg.Add(1)
 	go gremlinProtocol.readLoop(results, errorCallback)
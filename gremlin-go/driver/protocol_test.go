@@ -20,10 +20,18 @@ under the License.
 package gremlingo
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
 	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/apache/tinkerpop/gremlin-go/v3/driver/internal/wire"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 )
@@ -88,4 +96,147 @@ func TestProtocol(t *testing.T) {
 			// Ok. Close must not wait.
 		}
 	})
+
+	t.Run("Test protocol discards responses of cancelled requests", func(t *testing.T) {
+		protocol := &gremlinServerWSProtocol{
+			logHandler: newLogHandler(&defaultLogger{}, Info, language.English),
+		}
+		resultSets := &synchronizedMap{internalMap: map[string]ResultSet{}}
+		id := uuid.New()
+		resultSet := newChannelResultSet(id.String(), resultSets)
+		resultSets.store(id.String(), resultSet)
+		resultSet.cancel(context.Canceled)
+
+		partial := response{responseID: id, responseStatus: responseStatus{code: http.StatusPartialContent},
+			responseResult: responseResult{data: []interface{}{1}}}
+		final := response{responseID: id, responseStatus: responseStatus{code: http.StatusOK},
+			responseResult: responseResult{data: []interface{}{2}}}
+		assert.Nil(t, protocol.responseHandler(resultSets, partial))
+		assert.Nil(t, protocol.responseHandler(resultSets, final))
+		// Once the final response was discarded, the request ID is unknown again, which is logged and ignored.
+		assert.Nil(t, protocol.responseHandler(resultSets, final))
+	})
+
+	t.Run("Test protocol fails only the request of an unreadable response", func(t *testing.T) {
+		logHandler := newLogHandler(&defaultLogger{}, Off, language.English)
+		transporter := &pipeTransporter{t: t, responses: make(chan []byte, 4), done: make(chan struct{})}
+		protocol := &gremlinServerWSProtocol{
+			protocolBase: &protocolBase{transporter: transporter},
+			serializer:   newGraphBinarySerializer(logHandler),
+			logHandler:   logHandler,
+			mutex:        sync.Mutex{},
+			wg:           &sync.WaitGroup{},
+		}
+		resultSets := &synchronizedMap{internalMap: map[string]ResultSet{}}
+		failedID, healthyID := uuid.New(), uuid.New()
+		failed := newChannelResultSet(failedID.String(), resultSets)
+		healthy := newChannelResultSet(healthyID.String(), resultSets)
+		resultSets.store(failedID.String(), failed)
+		resultSets.store(healthyID.String(), healthy)
+
+		// The first part of the response holds a value of an unknown type, the rest of it must be discarded.
+		transporter.responses <- protocolTestResponse(t, failedID, http.StatusPartialContent, []byte{0xf0, 0x00})
+		transporter.responses <- protocolTestResponse(t, failedID, http.StatusOK, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x01})
+		transporter.responses <- protocolTestResponse(t, healthyID, http.StatusOK, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x02})
+
+		var lock sync.Mutex
+		errorCallbackCalled := false
+		protocol.wg.Add(1)
+		go protocol.readLoop(resultSets, func() {
+			lock.Lock()
+			defer lock.Unlock()
+			errorCallbackCalled = true
+		})
+
+		results, err := healthy.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, int32(2), results[0].GetInterface())
+		results, err = failed.All()
+		assert.NotNil(t, err)
+		assert.True(t, isSameErrorCode(err, newError(E0408GetSerializerToReadUnknownTypeError)))
+		assert.Empty(t, results)
+
+		lock.Lock()
+		assert.False(t, errorCallbackCalled)
+		lock.Unlock()
+		assert.False(t, transporter.IsClosed())
+		assert.Equal(t, 0, resultSets.size())
+
+		protocol.mutex.Lock()
+		protocol.closed = true
+		protocol.mutex.Unlock()
+		_ = transporter.Close()
+		protocol.wg.Wait()
+	})
+
+	t.Run("Test protocol streams responses of streaming transporters", func(t *testing.T) {
+		logHandler := newLogHandler(&defaultLogger{}, Off, language.English)
+		transporter := &streamingPipeTransporter{&pipeTransporter{t: t, responses: make(chan []byte, 2),
+			done: make(chan struct{})}}
+		protocol := &gremlinServerWSProtocol{
+			protocolBase: &protocolBase{transporter: transporter},
+			serializer:   newGraphBinarySerializer(logHandler),
+			logHandler:   logHandler,
+			mutex:        sync.Mutex{},
+			wg:           &sync.WaitGroup{},
+		}
+		resultSets := &synchronizedMap{internalMap: map[string]ResultSet{}}
+		id := uuid.New()
+		resultSet := newChannelResultSet(id.String(), resultSets)
+		resultSets.store(id.String(), resultSet)
+
+		partial, err := writeGraphBinaryResponse(&wire.ResponseMessage{RequestID: id, StatusCode: http.StatusPartialContent,
+			Data: []interface{}{"marko", int32(29)}})
+		assert.Nil(t, err)
+		final, err := writeGraphBinaryResponse(&wire.ResponseMessage{RequestID: id, StatusCode: http.StatusOK,
+			Data: []interface{}{map[interface{}]interface{}{"name": "vadas"}}})
+		assert.Nil(t, err)
+		transporter.responses <- partial
+		transporter.responses <- final
+
+		protocol.wg.Add(1)
+		go protocol.readLoop(resultSets, func() {})
+
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(results))
+		assert.Equal(t, "marko", results[0].GetInterface())
+		assert.Equal(t, int32(29), results[1].GetInterface())
+		assert.Equal(t, map[interface{}]interface{}{"name": "vadas"}, results[2].GetInterface())
+
+		protocol.mutex.Lock()
+		protocol.closed = true
+		protocol.mutex.Unlock()
+		_ = transporter.Close()
+		protocol.wg.Wait()
+	})
+}
+
+// streamingPipeTransporter is a pipeTransporter which returns its responses as streams.
+type streamingPipeTransporter struct {
+	*pipeTransporter
+}
+
+func (transporter *streamingPipeTransporter) NextReader() (io.Reader, error) {
+	response, err := transporter.Read()
+	if err != nil {
+		return nil, err
+	}
+	// The response is read one byte at a time, so that it is decoded while it is received.
+	return iotest.OneByteReader(bytes.NewReader(response)), nil
+}
+
+// protocolTestResponse creates a GraphBinary response message for requestID with the given fully qualified data.
+func protocolTestResponse(t *testing.T, requestID uuid.UUID, statusCode uint32, data []byte) []byte {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte(versionByte)
+	buffer.WriteByte(0)
+	buffer.Write(requestID[:])
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, statusCode))
+	buffer.WriteByte(valueFlagNull)
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, uint32(0)))
+	assert.Nil(t, binary.Write(&buffer, binary.BigEndian, uint32(0)))
+	buffer.Write(data)
+	return buffer.Bytes()
 }
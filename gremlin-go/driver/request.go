@@ -113,6 +113,20 @@ func makeBytecodeRequest(bytecodeGremlin *Bytecode, traversalSource string, sess
 	}
 }
 
+// makeScriptRequest creates an eval request of the parameterized script of a traversal. Request arguments set on the
+// traversal are sent along as they are with a bytecode request.
+func makeScriptRequest(script string, bindings map[string]interface{}, bytecode *Bytecode, traversalSource string,
+	sessionId string) (req request) {
+	if len(bindings) == 0 {
+		bindings = nil
+	}
+	req = makeStringRequest(script, traversalSource, sessionId, RequestOptions{bindings: bindings})
+	for k, v := range extractReqArgs(bytecode) {
+		req.args[k] = v
+	}
+	return req
+}
+
 // allowedReqArgs contains the arguments that will be extracted from the
 // bytecode and sent with the request.
 var allowedReqArgs = map[string]bool{
@@ -30,6 +30,7 @@ type RequestOptions struct {
 	userAgent             string
 	bindings              map[string]interface{}
 	materializeProperties string
+	retry                 bool
 }
 
 type RequestOptionsBuilder struct {
@@ -39,6 +40,7 @@ type RequestOptionsBuilder struct {
 	userAgent             string
 	bindings              map[string]interface{}
 	materializeProperties string
+	retry                 bool
 }
 
 func (builder *RequestOptionsBuilder) SetRequestId(requestId uuid.UUID) *RequestOptionsBuilder {
@@ -71,6 +73,13 @@ func (builder *RequestOptionsBuilder) SetMaterializeProperties(materializeProper
 	return builder
 }
 
+// SetRetry opts the request in to being retried according to the RetryPolicy of the Client after transient failures.
+// Only scripts without side effects should be retried.
+func (builder *RequestOptionsBuilder) SetRetry(retry bool) *RequestOptionsBuilder {
+	builder.retry = retry
+	return builder
+}
+
 func (builder *RequestOptionsBuilder) AddBinding(key string, binding interface{}) *RequestOptionsBuilder {
 	if builder.bindings == nil {
 		builder.bindings = make(map[string]interface{})
@@ -88,6 +97,7 @@ func (builder *RequestOptionsBuilder) Create() RequestOptions {
 	requestOptions.userAgent = builder.userAgent
 	requestOptions.bindings = builder.bindings
 	requestOptions.materializeProperties = builder.materializeProperties
+	requestOptions.retry = builder.retry
 
 	return *requestOptions
 }
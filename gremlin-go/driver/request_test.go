@@ -63,4 +63,27 @@ func TestRequest(t *testing.T) {
 		assert.NotEqual(t, uuid.Nil, r.requestID)
 		assert.Equal(t, "TestUserAgent", r.args["userAgent"])
 	})
+
+	t.Run("Test makeScriptRequest()", func(t *testing.T) {
+		bytecode := NewBytecode(nil)
+		bytecode.AddSource("with", "evaluationTimeout", 1234)
+		bytecode.AddStep("V")
+		r := makeScriptRequest("g.with(_args_0,_args_1).V()",
+			map[string]interface{}{"_args_0": "evaluationTimeout", "_args_1": int32(1234)}, bytecode, "social", "")
+		assert.Equal(t, stringOp, r.op)
+		assert.Equal(t, stringProcessor, r.processor)
+		assert.Equal(t, "g.with(_args_0,_args_1).V()", r.args["gremlin"])
+		assert.Equal(t, map[string]interface{}{"g": "social"}, r.args["aliases"])
+		assert.Equal(t, map[string]interface{}{"_args_0": "evaluationTimeout", "_args_1": int32(1234)},
+			r.args["bindings"])
+		assert.Equal(t, 1234, r.args["evaluationTimeout"])
+	})
+
+	t.Run("Test makeScriptRequest() without bindings in session", func(t *testing.T) {
+		r := makeScriptRequest("g.tx().commit()", map[string]interface{}{}, NewBytecode(nil), "g", "session")
+		assert.Equal(t, sessionProcessor, r.processor)
+		assert.Equal(t, "session", r.args["session"])
+		_, ok := r.args["bindings"]
+		assert.False(t, ok)
+	})
 }
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import "fmt"
+
+// ResponseError is the error returned when the server responds to a request with a status other than a success. It can
+// be obtained from the errors returned by the driver with errors.As.
+type ResponseError struct {
+	// RequestID is the id of the failed request.
+	RequestID string
+	// StatusCode is the status code of the response, such as 597 for a script evaluation error or 598 for a timeout.
+	StatusCode uint16
+	// StatusMessage is the message describing the failure.
+	StatusMessage string
+	// Exceptions holds the class names of the exceptions raised on the server, from the "exceptions" status attribute.
+	Exceptions []string
+	// StackTrace is the stack trace of the server-side exception, from the "stackTrace" status attribute.
+	StackTrace string
+	// Attributes holds all status attributes of the response.
+	Attributes map[string]interface{}
+}
+
+func newResponseError(response response) *ResponseError {
+	responseError := &ResponseError{
+		RequestID:     response.responseID.String(),
+		StatusCode:    response.responseStatus.code,
+		StatusMessage: response.responseStatus.message,
+		Attributes:    response.responseStatus.attributes,
+	}
+	if exceptions, ok := response.responseStatus.attributes["exceptions"].([]interface{}); ok {
+		for _, exception := range exceptions {
+			responseError.Exceptions = append(responseError.Exceptions, fmt.Sprint(exception))
+		}
+	}
+	if stackTrace, ok := response.responseStatus.attributes["stackTrace"].(string); ok {
+		responseError.StackTrace = stackTrace
+	}
+	return responseError
+}
+
+func (responseError *ResponseError) Error() string {
+	return newError(E0502ResponseHandlerReadLoopError, responseError.StatusMessage, responseError.StatusCode).Error()
+}
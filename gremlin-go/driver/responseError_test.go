@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestResponseError(t *testing.T) {
+	id := uuid.New()
+	errorResponse := response{
+		responseID: id,
+		responseStatus: responseStatus{
+			code:    598,
+			message: "A timeout occurred during traversal evaluation",
+			attributes: map[string]interface{}{
+				"exceptions": []interface{}{"java.util.concurrent.TimeoutException"},
+				"stackTrace": "java.util.concurrent.TimeoutException\n\tat ...",
+			},
+		},
+	}
+
+	t.Run("Test newResponseError", func(t *testing.T) {
+		responseError := newResponseError(errorResponse)
+		assert.Equal(t, id.String(), responseError.RequestID)
+		assert.Equal(t, uint16(598), responseError.StatusCode)
+		assert.Equal(t, "A timeout occurred during traversal evaluation", responseError.StatusMessage)
+		assert.Equal(t, []string{"java.util.concurrent.TimeoutException"}, responseError.Exceptions)
+		assert.Equal(t, "java.util.concurrent.TimeoutException\n\tat ...", responseError.StackTrace)
+		assert.Equal(t, errorResponse.responseStatus.attributes, responseError.Attributes)
+		assert.True(t, isSameErrorCode(newError(E0502ResponseHandlerReadLoopError), responseError))
+		assert.Contains(t, responseError.Error(), "statusCode: 598")
+	})
+
+	t.Run("Test error response fails ResultSet with ResponseError", func(t *testing.T) {
+		protocol := &gremlinServerWSProtocol{
+			logHandler: newLogHandler(&defaultLogger{}, Off, language.English),
+		}
+		resultSets := &synchronizedMap{internalMap: map[string]ResultSet{}}
+		resultSet := newChannelResultSet(id.String(), resultSets)
+		resultSets.store(id.String(), resultSet)
+
+		assert.Nil(t, protocol.responseHandler(resultSets, errorResponse))
+		_, err := resultSet.All()
+		wrapped := fmt.Errorf("query failed: %w", err)
+
+		var responseError *ResponseError
+		assert.True(t, errors.As(wrapped, &responseError))
+		assert.Equal(t, uint16(598), responseError.StatusCode)
+		assert.Equal(t, id.String(), responseError.RequestID)
+	})
+}
@@ -152,7 +152,7 @@ func (r *Result) IsNil() bool {
 func (r *Result) GetVertex() (*Vertex, error) {
 	res, ok := r.Data.(*Vertex)
 	if !ok {
-		return nil, newError(err0601ResultNotVertexError)
+		return nil, newError(E0601ResultNotVertexError)
 	}
 	return res, nil
 }
@@ -161,7 +161,7 @@ func (r *Result) GetVertex() (*Vertex, error) {
 func (r *Result) GetEdge() (*Edge, error) {
 	res, ok := r.Data.(*Edge)
 	if !ok {
-		return nil, newError(err0602ResultNotEdgeError)
+		return nil, newError(E0602ResultNotEdgeError)
 	}
 	return res, nil
 }
@@ -170,7 +170,7 @@ func (r *Result) GetEdge() (*Edge, error) {
 func (r *Result) GetElement() (*Element, error) {
 	res, ok := r.Data.(*Element)
 	if !ok {
-		return nil, newError(err0603ResultNotElementError)
+		return nil, newError(E0603ResultNotElementError)
 	}
 	return res, nil
 }
@@ -179,7 +179,7 @@ func (r *Result) GetElement() (*Element, error) {
 func (r *Result) GetPath() (*Path, error) {
 	res, ok := r.Data.(*Path)
 	if !ok {
-		return nil, newError(err0604ResultNotPathError)
+		return nil, newError(E0604ResultNotPathError)
 	}
 	return res, nil
 }
@@ -188,7 +188,7 @@ func (r *Result) GetPath() (*Path, error) {
 func (r *Result) GetProperty() (*Property, error) {
 	res, ok := r.Data.(*Property)
 	if !ok {
-		return nil, newError(err0605ResultNotPropertyError)
+		return nil, newError(E0605ResultNotPropertyError)
 	}
 	return res, nil
 }
@@ -197,25 +197,39 @@ func (r *Result) GetProperty() (*Property, error) {
 func (r *Result) GetVertexProperty() (*VertexProperty, error) {
 	res, ok := r.Data.(*VertexProperty)
 	if !ok {
-		return nil, newError(err0606ResultNotVertexPropertyError)
+		return nil, newError(E0606ResultNotVertexPropertyError)
 	}
 	return res, nil
 }
 
-// GetTraverser returns the Result if it is a Traverser, otherwise returns an error.
+// GetTraverser returns the Result if it is a Traverser, otherwise returns an error. Traversers are only returned as
+// results if PreserveBulk is set.
 func (r *Result) GetTraverser() (*Traverser, error) {
-	res, ok := r.Data.(Traverser)
+	switch res := r.Data.(type) {
+	case *Traverser:
+		return res, nil
+	case Traverser:
+		return &res, nil
+	default:
+		return nil, newError(E0607ResultNotTraverserError)
+	}
+}
+
+// GetBulkSet returns the Result if it is a BulkSet, otherwise returns an error. BulkSets are only returned as results
+// if PreserveBulk is set.
+func (r *Result) GetBulkSet() (*BulkSet, error) {
+	res, ok := r.Data.(*BulkSet)
 	if !ok {
-		return nil, newError(err0607ResultNotTraverserError)
+		return nil, newError(E0609ResultNotBulkSetError)
 	}
-	return &res, nil
+	return res, nil
 }
 
 // GetSlice returns the Result if it is a Slice, otherwise returns an error.
 func (r *Result) GetSlice() (*[]interface{}, error) {
 	res, ok := r.Data.([]interface{})
 	if !ok {
-		return nil, newError(err0608ResultNotSliceError)
+		return nil, newError(E0608ResultNotSliceError)
 	}
 	return &res, nil
 }
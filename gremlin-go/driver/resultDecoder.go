@@ -0,0 +1,515 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	gremlinTagName         = "gremlin"
+	maxDecodedDecimalScale = 1 << 12
+)
+
+var (
+	bigIntReflectType     = reflect.TypeOf(big.Int{})
+	bigDecimalReflectType = reflect.TypeOf(BigDecimal{})
+	structFieldsCache     sync.Map
+)
+
+// gremlinTag is the `gremlin` tag of a struct field, such as `gremlin:"name"`. The options following the name may
+// map the field to T.id or T.label instead of a property, as in `gremlin:"id,T.id"`, omit empty values when the struct
+// is written, as in `gremlin:"name,omitempty"`, or give the cardinality of the property, as in `gremlin:"nicks,list"`.
+type gremlinTag struct {
+	name        string
+	token       t
+	omitEmpty   bool
+	cardinality cardinality
+}
+
+func parseGremlinTag(tag string) gremlinTag {
+	parts := strings.Split(tag, ",")
+	parsed := gremlinTag{name: parts[0]}
+	for _, option := range parts[1:] {
+		switch option {
+		case "T.id":
+			parsed.token = T.Id
+		case "T.label":
+			parsed.token = T.Label
+		case "omitempty":
+			parsed.omitEmpty = true
+		case string(Cardinality.Single), string(Cardinality.List), string(Cardinality.Set):
+			parsed.cardinality = cardinality(option)
+		}
+	}
+	return parsed
+}
+
+// structField is a field of a struct which results are decoded into.
+type structField struct {
+	index []int
+	name  string
+	tag   gremlinTag
+	// tagged is false if the key of the field is its name, which is then matched case-insensitively.
+	tagged bool
+}
+
+// structFields returns the fields of a struct type which are decoded from results. Fields of embedded structs are
+// treated as fields of the struct itself, as with encoding/json.
+func structFields(typ reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(typ); ok {
+		return fields.([]structField)
+	}
+	fields := appendStructFields(nil, typ, nil)
+	structFieldsCache.Store(typ, fields)
+	return fields
+}
+
+func appendStructFields(fields []structField, typ reflect.Type, index []int) []structField {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, tagged := field.Tag.Lookup(gremlinTagName)
+		if tag == "-" {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			fields = appendStructFields(fields, field.Type, fieldIndex)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		parsed := parseGremlinTag(tag)
+		if parsed.name == "" {
+			parsed.name = field.Name
+			tagged = false
+		}
+		fields = append(fields, structField{index: fieldIndex, name: field.Name, tag: parsed, tagged: tagged})
+	}
+	return fields
+}
+
+// Decode stores the result in the value pointed to by dst. Maps, such as the results of ValueMap(), ElementMap() and
+// Project(), and elements along with their properties are decoded into structs, whose fields are matched to keys by
+// their `gremlin` tag, as in `gremlin:"name"`, or else by their name. A field tagged `gremlin:"id,T.id"` or
+// `gremlin:"label,T.label"` takes the id or the label. Lists with a single item, such as property values of a
+// ValueMap(), are decoded as that item into fields which are not slices, and numbers are converted to any numeric type
+// which holds their value.
+func (r *Result) Decode(dst interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return newError(E0610ResultDecodeInvalidTargetError, dst)
+	}
+	return decodeValue(r.Data, value.Elem(), value.Elem().Type().String())
+}
+
+// decodeAll decodes the results into the slice pointed to by dst.
+func decodeAll(results []*Result, dst interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return newError(E0611ResultDecodeAllInvalidTargetError, dst)
+	}
+	slice := value.Elem()
+	decoded := reflect.MakeSlice(slice.Type(), len(results), len(results))
+	for i, result := range results {
+		if err := decodeValue(result.Data, decoded.Index(i), slice.Type().String()+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	slice.Set(reflect.AppendSlice(slice, decoded))
+	return nil
+}
+
+// decodeValue stores src in dst, converting it as described by Result.Decode. The path names dst in errors.
+func decodeValue(src interface{}, dst reflect.Value, path string) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(dst.Type()) {
+		dst.Set(srcValue)
+		return nil
+	}
+	if srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() && srcValue.Elem().Type().AssignableTo(dst.Type()) {
+		dst.Set(srcValue.Elem())
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(src, dst.Elem(), path)
+	}
+	if unwrapped, ok := unwrapResultValue(src, dst.Type()); ok {
+		return decodeValue(unwrapped, dst, path)
+	}
+
+	switch dst.Type() {
+	case bigIntReflectType, bigDecimalReflectType:
+		return decodeNumber(src, dst, path)
+	}
+	switch dst.Kind() {
+	case reflect.Struct:
+		entries, ok := resultEntries(src)
+		if !ok {
+			break
+		}
+		return decodeStruct(entries, dst, path)
+	case reflect.Map:
+		entries, ok := resultEntries(src)
+		if !ok {
+			break
+		}
+		return decodeMap(entries, dst, path)
+	case reflect.Slice, reflect.Array:
+		items, ok := resultItems(src)
+		if !ok {
+			break
+		}
+		return decodeSlice(items, dst, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return decodeNumber(src, dst, path)
+	case reflect.String:
+		if srcValue.Kind() == reflect.String {
+			dst.SetString(srcValue.String())
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := src.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	}
+	return newError(E0612ResultDecodeTypeMismatchError, src, dst.Type(), path)
+}
+
+// unwrapResultValue returns the value held by src if it is a list with a single item, a property or a traverser, and
+// dst cannot hold src itself.
+func unwrapResultValue(src interface{}, dst reflect.Type) (interface{}, bool) {
+	switch value := src.(type) {
+	case []interface{}:
+		if len(value) == 1 && dst.Kind() != reflect.Slice && dst.Kind() != reflect.Array {
+			return value[0], true
+		}
+	case *VertexProperty:
+		if dst.Kind() != reflect.Struct && dst.Kind() != reflect.Map {
+			return value.Value, true
+		}
+	case *Property:
+		return value.Value, true
+	case *Traverser:
+		return value.value, true
+	case Traverser:
+		return value.value, true
+	}
+	return nil, false
+}
+
+// resultEntries returns src as a map, if it is a map or an element. The entries of an element are its id, its label
+// and its properties, where each vertex property key has a list of the values of its vertex properties.
+func resultEntries(src interface{}) (map[interface{}]interface{}, bool) {
+	switch value := src.(type) {
+	case map[interface{}]interface{}:
+		return value, true
+	case *Vertex:
+		return elementEntries(&value.Element), true
+	case *Edge:
+		return elementEntries(&value.Element), true
+	case *VertexProperty:
+		entries := elementEntries(&value.Element)
+		entries[T.Key] = value.Key
+		entries[T.Value] = value.Value
+		return entries, true
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() != reflect.Map {
+		return nil, false
+	}
+	entries := make(map[interface{}]interface{}, srcValue.Len())
+	iter := srcValue.MapRange()
+	for iter.Next() {
+		entries[iter.Key().Interface()] = iter.Value().Interface()
+	}
+	return entries, true
+}
+
+func elementEntries(element *Element) map[interface{}]interface{} {
+	entries := map[interface{}]interface{}{T.Id: element.Id, T.Label: element.Label}
+	properties, _ := element.Properties.([]interface{})
+	for _, property := range properties {
+		switch p := property.(type) {
+		case *VertexProperty:
+			values, _ := entries[p.Key].([]interface{})
+			entries[p.Key] = append(values, p.Value)
+		case *Property:
+			entries[p.Key] = p.Value
+		}
+	}
+	return entries
+}
+
+// resultItems returns src as a list, if it is a list or a set.
+func resultItems(src interface{}) ([]interface{}, bool) {
+	switch value := src.(type) {
+	case []interface{}:
+		return value, true
+	case *BulkSet:
+		return value.Expand(), true
+	case Set:
+		return value.ToSlice(), true
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() != reflect.Slice && srcValue.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, srcValue.Len())
+	for i := range items {
+		items[i] = srcValue.Index(i).Interface()
+	}
+	return items, true
+}
+
+func decodeStruct(entries map[interface{}]interface{}, dst reflect.Value, path string) error {
+	byName := make(map[string]interface{}, len(entries))
+	byToken := make(map[t]interface{}, 2)
+	for key, value := range entries {
+		switch k := key.(type) {
+		case t:
+			byToken[k] = value
+		case string:
+			byName[k] = value
+		}
+	}
+	for _, field := range structFields(dst.Type()) {
+		value, ok := field.lookup(byName, byToken)
+		if !ok {
+			continue
+		}
+		if err := decodeValue(value, dst.FieldByIndex(field.index), path+"."+field.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup finds the value of the field. As enums such as T.id are read as strings, the key of a field also matches the
+// token of the same name, and the other way around.
+func (field *structField) lookup(byName map[string]interface{}, byToken map[t]interface{}) (interface{}, bool) {
+	if field.tag.token != "" {
+		if value, ok := byToken[field.tag.token]; ok {
+			return value, true
+		}
+		if value, ok := byName[string(field.tag.token)]; ok {
+			return value, true
+		}
+	}
+	if value, ok := byName[field.tag.name]; ok {
+		return value, true
+	}
+	if value, ok := byToken[t(field.tag.name)]; ok {
+		return value, true
+	}
+	if !field.tagged {
+		for key, value := range byName {
+			if strings.EqualFold(key, field.tag.name) {
+				return value, true
+			}
+		}
+		for key, value := range byToken {
+			if strings.EqualFold(string(key), field.tag.name) {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func decodeMap(entries map[interface{}]interface{}, dst reflect.Value, path string) error {
+	typ := dst.Type()
+	decoded := reflect.MakeMapWithSize(typ, len(entries))
+	for key, value := range entries {
+		k := reflect.New(typ.Key()).Elem()
+		if err := decodeValue(key, k, path+" key"); err != nil {
+			return err
+		}
+		v := reflect.New(typ.Elem()).Elem()
+		if err := decodeValue(value, v, fmt.Sprintf("%s[%v]", path, key)); err != nil {
+			return err
+		}
+		decoded.SetMapIndex(k, v)
+	}
+	dst.Set(decoded)
+	return nil
+}
+
+func decodeSlice(items []interface{}, dst reflect.Value, path string) error {
+	decoded := dst
+	if dst.Kind() == reflect.Slice {
+		decoded = reflect.MakeSlice(dst.Type(), len(items), len(items))
+	} else if len(items) > dst.Len() {
+		return newError(E0612ResultDecodeTypeMismatchError, items, dst.Type(), path)
+	}
+	for i, item := range items {
+		if err := decodeValue(item, decoded.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	dst.Set(decoded)
+	return nil
+}
+
+// decodeNumber stores the number src in dst, as long as dst can hold its value.
+func decodeNumber(src interface{}, dst reflect.Value, path string) error {
+	number, ok := toBigRat(src)
+	if !ok {
+		return newError(E0612ResultDecodeTypeMismatchError, src, dst.Type(), path)
+	}
+	overflow := func() error {
+		return newError(E0613ResultDecodeOverflowError, src, dst.Type(), path)
+	}
+	switch dst.Type() {
+	case bigIntReflectType:
+		if !number.IsInt() {
+			return overflow()
+		}
+		dst.Set(reflect.ValueOf(*new(big.Int).Set(number.Num())))
+		return nil
+	case bigDecimalReflectType:
+		decimal, ok := toBigDecimal(src, number)
+		if !ok {
+			return overflow()
+		}
+		dst.Set(reflect.ValueOf(*decimal))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !number.IsInt() || !number.Num().IsInt64() || dst.OverflowInt(number.Num().Int64()) {
+			return overflow()
+		}
+		dst.SetInt(number.Num().Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !number.IsInt() || !number.Num().IsUint64() || dst.OverflowUint(number.Num().Uint64()) {
+			return overflow()
+		}
+		dst.SetUint(number.Num().Uint64())
+	default:
+		f, _ := number.Float64()
+		if math.IsInf(f, 0) || dst.OverflowFloat(f) {
+			return overflow()
+		}
+		dst.SetFloat(f)
+	}
+	return nil
+}
+
+// toBigRat returns the value of a number of any type which is read from results.
+func toBigRat(src interface{}) (*big.Rat, bool) {
+	switch value := src.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(value)), true
+	case int64:
+		return new(big.Rat).SetInt64(value), true
+	case uint:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint8:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint16:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint32:
+		return new(big.Rat).SetUint64(uint64(value)), true
+	case uint64:
+		return new(big.Rat).SetUint64(value), true
+	case float32:
+		return floatToBigRat(float64(value))
+	case float64:
+		return floatToBigRat(value)
+	case *big.Int:
+		return new(big.Rat).SetInt(value), true
+	case big.Int:
+		return new(big.Rat).SetInt(&value), true
+	case *BigDecimal:
+		return bigDecimalToBigRat(value)
+	case BigDecimal:
+		return bigDecimalToBigRat(&value)
+	}
+	return nil, false
+}
+
+func floatToBigRat(value float64) (*big.Rat, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, false
+	}
+	return new(big.Rat).SetFloat64(value), true
+}
+
+// bigDecimalToBigRat returns the value of a BigDecimal, unless its scale is too large to compute it with reasonable
+// effort.
+func bigDecimalToBigRat(value *BigDecimal) (*big.Rat, bool) {
+	if absInt32(value.Scale) > maxDecodedDecimalScale {
+		return nil, false
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(absInt32(value.Scale)), nil)
+	if value.Scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(&value.UnscaledValue, scale)), true
+	}
+	return new(big.Rat).SetFrac(&value.UnscaledValue, scale), true
+}
+
+// toBigDecimal converts a number to a BigDecimal. Floats are converted by their shortest decimal representation, as
+// 0.1 is 0.1 rather than the exact value of its binary approximation.
+func toBigDecimal(src interface{}, number *big.Rat) (*BigDecimal, bool) {
+	switch value := src.(type) {
+	case *BigDecimal:
+		return value, true
+	case BigDecimal:
+		return &value, true
+	case float32:
+		decimal, err := parseBigDecimal(strconv.FormatFloat(float64(value), 'f', -1, 32))
+		return decimal, err == nil
+	case float64:
+		decimal, err := parseBigDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+		return decimal, err == nil
+	}
+	return &BigDecimal{UnscaledValue: *new(big.Int).Set(number.Num())}, number.IsInt()
+}
+
+func absInt32(value int32) int64 {
+	if value < 0 {
+		return -int64(value)
+	}
+	return int64(value)
+}
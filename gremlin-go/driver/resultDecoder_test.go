@@ -0,0 +1,230 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type decodeTestAddress struct {
+	City string `gremlin:"city"`
+	Zip  *int32 `gremlin:"zip"`
+}
+
+type decodeTestBase struct {
+	ID    int64  `gremlin:"id,T.id"`
+	Label string `gremlin:"label,T.label"`
+}
+
+type decodeTestPerson struct {
+	decodeTestBase
+	Name     string             `gremlin:"name"`
+	Age      int64              `gremlin:"age"`
+	Score    float64            `gremlin:"score"`
+	Nicks    []string           `gremlin:"nicks"`
+	Address  decodeTestAddress  `gremlin:"address"`
+	Friends  []decodeTestPerson `gremlin:"friends"`
+	Country  string
+	Ignored  string `gremlin:"-"`
+	internal string
+}
+
+func TestResultDecode(t *testing.T) {
+	t.Run("Test Decode ValueMap", func(t *testing.T) {
+		result := &Result{map[interface{}]interface{}{
+			T.Id:      int32(1),
+			T.Label:   "person",
+			"name":    []interface{}{"marko"},
+			"age":     []interface{}{int32(29)},
+			"score":   []interface{}{float32(0.5)},
+			"nicks":   []interface{}{"mark", "okram"},
+			"country": []interface{}{"US"},
+			"Ignored": []interface{}{"value"},
+		}}
+		var person decodeTestPerson
+		assert.Nil(t, result.Decode(&person))
+		assert.Equal(t, decodeTestPerson{
+			decodeTestBase: decodeTestBase{ID: 1, Label: "person"},
+			Name:           "marko",
+			Age:            29,
+			Score:          0.5,
+			Nicks:          []string{"mark", "okram"},
+			Country:        "US",
+		}, person)
+	})
+
+	t.Run("Test Decode ValueMap with string id and label keys", func(t *testing.T) {
+		result := &Result{map[interface{}]interface{}{"id": int64(7), "label": "person"}}
+		var person decodeTestPerson
+		assert.Nil(t, result.Decode(&person))
+		assert.Equal(t, decodeTestBase{ID: 7, Label: "person"}, person.decodeTestBase)
+	})
+
+	t.Run("Test Decode nested Project", func(t *testing.T) {
+		result := &Result{map[interface{}]interface{}{
+			"name":    "marko",
+			"address": map[interface{}]interface{}{"city": "Santa Fe", "zip": []interface{}{int64(87501)}},
+			"friends": []interface{}{
+				map[interface{}]interface{}{"name": []interface{}{"vadas"}},
+				map[interface{}]interface{}{"name": []interface{}{"josh"}},
+			},
+		}}
+		var person decodeTestPerson
+		assert.Nil(t, result.Decode(&person))
+		assert.Equal(t, "Santa Fe", person.Address.City)
+		assert.Equal(t, int32(87501), *person.Address.Zip)
+		assert.Equal(t, []decodeTestPerson{{Name: "vadas"}, {Name: "josh"}}, person.Friends)
+	})
+
+	t.Run("Test Decode Vertex", func(t *testing.T) {
+		vertex := &Vertex{Element{Id: int64(1), Label: "person", Properties: []interface{}{
+			&VertexProperty{Key: "name", Value: "marko"},
+			&VertexProperty{Key: "nicks", Value: "mark"},
+			&VertexProperty{Key: "nicks", Value: "okram"},
+		}}}
+		var person decodeTestPerson
+		assert.Nil(t, (&Result{vertex}).Decode(&person))
+		assert.Equal(t, decodeTestPerson{
+			decodeTestBase: decodeTestBase{ID: 1, Label: "person"},
+			Name:           "marko",
+			Nicks:          []string{"mark", "okram"},
+		}, person)
+
+		var v Vertex
+		assert.Nil(t, (&Result{vertex}).Decode(&v))
+		assert.Equal(t, *vertex, v)
+	})
+
+	t.Run("Test Decode Edge", func(t *testing.T) {
+		var knows struct {
+			ID     interface{} `gremlin:"id,T.id"`
+			Weight float64     `gremlin:"weight"`
+		}
+		edge := &Edge{Element: Element{Id: "e1", Label: "knows", Properties: []interface{}{
+			&Property{Key: "weight", Value: 0.5},
+		}}}
+		assert.Nil(t, (&Result{edge}).Decode(&knows))
+		assert.Equal(t, "e1", knows.ID)
+		assert.Equal(t, 0.5, knows.Weight)
+	})
+
+	t.Run("Test Decode scalars", func(t *testing.T) {
+		var i int
+		assert.Nil(t, (&Result{int32(5)}).Decode(&i))
+		assert.Equal(t, 5, i)
+		var f float64
+		assert.Nil(t, (&Result{int64(5)}).Decode(&f))
+		assert.Equal(t, 5.0, f)
+		var u uint8
+		assert.Nil(t, (&Result{big.NewInt(200)}).Decode(&u))
+		assert.Equal(t, uint8(200), u)
+		var s string
+		assert.Nil(t, (&Result{[]interface{}{"marko"}}).Decode(&s))
+		assert.Equal(t, "marko", s)
+		var token string
+		assert.Nil(t, (&Result{T.Label}).Decode(&token))
+		assert.Equal(t, "label", token)
+		var any interface{}
+		assert.Nil(t, (&Result{[]interface{}{"marko"}}).Decode(&any))
+		assert.Equal(t, []interface{}{"marko"}, any)
+		var p *int64
+		assert.Nil(t, (&Result{int8(3)}).Decode(&p))
+		assert.Equal(t, int64(3), *p)
+		assert.Nil(t, (&Result{nil}).Decode(&p))
+		assert.Nil(t, p)
+	})
+
+	t.Run("Test Decode big numbers", func(t *testing.T) {
+		var bigInt *big.Int
+		assert.Nil(t, (&Result{int64(42)}).Decode(&bigInt))
+		assert.Equal(t, big.NewInt(42), bigInt)
+		var decimal BigDecimal
+		assert.Nil(t, (&Result{0.25}).Decode(&decimal))
+		assert.Equal(t, BigDecimal{Scale: 2, UnscaledValue: *big.NewInt(25)}, decimal)
+		var i int64
+		assert.Nil(t, (&Result{&BigDecimal{Scale: 1, UnscaledValue: *big.NewInt(120)}}).Decode(&i))
+		assert.Equal(t, int64(12), i)
+		var f float64
+		assert.Nil(t, (&Result{&BigDecimal{Scale: 2, UnscaledValue: *big.NewInt(125)}}).Decode(&f))
+		assert.Equal(t, 1.25, f)
+	})
+
+	t.Run("Test Decode map", func(t *testing.T) {
+		var counts map[string]int64
+		assert.Nil(t, (&Result{map[interface{}]interface{}{"person": int64(4), "software": int32(2)}}).Decode(&counts))
+		assert.Equal(t, map[string]int64{"person": 4, "software": 2}, counts)
+	})
+
+	t.Run("Test Decode set and bulk set", func(t *testing.T) {
+		var names []string
+		assert.Nil(t, (&Result{NewSimpleSet("marko", "vadas")}).Decode(&names))
+		assert.ElementsMatch(t, []string{"marko", "vadas"}, names)
+		bulkSet := &BulkSet{}
+		bulkSet.Add(int32(29), 2)
+		var ages []int
+		assert.Nil(t, (&Result{bulkSet}).Decode(&ages))
+		assert.Equal(t, []int{29, 29}, ages)
+	})
+
+	t.Run("Test Decode invalid target failure", func(t *testing.T) {
+		var person decodeTestPerson
+		assert.True(t, isSameErrorCode(newError(E0610ResultDecodeInvalidTargetError), (&Result{1}).Decode(person)))
+		assert.True(t, isSameErrorCode(newError(E0610ResultDecodeInvalidTargetError), (&Result{1}).Decode(nil)))
+	})
+
+	t.Run("Test Decode type mismatch failure", func(t *testing.T) {
+		var person decodeTestPerson
+		err := (&Result{map[interface{}]interface{}{"age": []interface{}{"old"}}}).Decode(&person)
+		assert.True(t, isSameErrorCode(newError(E0612ResultDecodeTypeMismatchError), err))
+		assert.Contains(t, err.Error(), "gremlingo.decodeTestPerson.Age")
+		var names []string
+		err = (&Result{map[interface{}]interface{}{}}).Decode(&names)
+		assert.True(t, isSameErrorCode(newError(E0612ResultDecodeTypeMismatchError), err))
+		var i int
+		err = (&Result{[]interface{}{int32(1), int32(2)}}).Decode(&i)
+		assert.True(t, isSameErrorCode(newError(E0612ResultDecodeTypeMismatchError), err))
+	})
+
+	t.Run("Test Decode overflow failure", func(t *testing.T) {
+		var i8 int8
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), (&Result{int32(300)}).Decode(&i8)))
+		var u uint
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), (&Result{int32(-1)}).Decode(&u)))
+		var i int
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), (&Result{1.5}).Decode(&i)))
+		var f float32
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), (&Result{1e300}).Decode(&f)))
+	})
+
+	t.Run("Test decodeAll", func(t *testing.T) {
+		names := []string{"josh"}
+		results := []*Result{{[]interface{}{"marko"}}, {"vadas"}}
+		assert.Nil(t, decodeAll(results, &names))
+		assert.Equal(t, []string{"josh", "marko", "vadas"}, names)
+		assert.True(t, isSameErrorCode(newError(E0611ResultDecodeAllInvalidTargetError), decodeAll(results, &Result{})))
+		var ages []int
+		err := decodeAll(results, &ages)
+		assert.True(t, isSameErrorCode(newError(E0612ResultDecodeTypeMismatchError), err))
+		assert.Contains(t, err.Error(), "[]int[0]")
+	})
+}
@@ -20,6 +20,7 @@ under the License.
 package gremlingo
 
 import (
+	"context"
 	"reflect"
 	"sync"
 )
@@ -39,9 +40,19 @@ type ResultSet interface {
 	Channel() chan *Result
 	addResult(result *Result)
 	One() (*Result, bool, error)
+	OneContext(ctx context.Context) (*Result, bool, error)
 	All() ([]*Result, error)
+	AllContext(ctx context.Context) ([]*Result, error)
+	DecodeAll(dst interface{}) error
+	Iter() Seq[*Result]
+	IterContext(ctx context.Context) Seq[*Result]
 	GetError() error
 	setError(error)
+	OnComplete(callback func())
+	complete()
+	isEmptyContext(ctx context.Context) (bool, error)
+	cancel(err error)
+	bindContext(ctx context.Context)
 }
 
 // channelResultSet Channel based implementation of ResultSet.
@@ -56,6 +67,10 @@ type channelResultSet struct {
 	waitSignal       chan bool
 	channelMutex     sync.Mutex
 	waitSignalMutex  sync.Mutex
+	done             chan struct{}
+	doneOnce         sync.Once
+	onComplete       []func()
+	preserveBulk     bool
 }
 
 func (channelResultSet *channelResultSet) sendSignal() {
@@ -79,16 +94,23 @@ func (channelResultSet *channelResultSet) setError(err error) {
 
 // IsEmpty returns true when the channelResultSet is empty.
 func (channelResultSet *channelResultSet) IsEmpty() bool {
+	empty, _ := channelResultSet.isEmptyContext(context.Background())
+	return empty
+}
+
+// isEmptyContext is IsEmpty, except that it stops waiting for the state to change once ctx is done. In that case the
+// channelResultSet is cancelled and ctx.Err() is returned.
+func (channelResultSet *channelResultSet) isEmptyContext(ctx context.Context) (bool, error) {
 	channelResultSet.channelMutex.Lock()
 	// If our channel is empty and we have no data in it, wait for signal that the state has been updated.
 	if len(channelResultSet.channel) != 0 {
 		// Channel is not empty.
 		channelResultSet.channelMutex.Unlock()
-		return false
+		return false, nil
 	} else if channelResultSet.closed {
 		// Channel is empty and closed.
 		channelResultSet.channelMutex.Unlock()
-		return true
+		return true, nil
 	} else {
 		// Channel is empty and not closed. Need to wait for signal that state has changed, otherwise
 		// we do not know if it is empty or not.
@@ -96,16 +118,22 @@ func (channelResultSet *channelResultSet) IsEmpty() bool {
 		channelResultSet.waitSignalMutex.Lock()
 		channelResultSet.channelMutex.Unlock()
 
-		// Create a wait signal and unlock the wait signal mutex.
-		waitSignal := make(chan bool)
+		// Create a wait signal and unlock the wait signal mutex. The wait signal is buffered so that the sender is
+		// never blocked if the waiter has given up because its context is done.
+		waitSignal := make(chan bool, 1)
 		channelResultSet.waitSignal = waitSignal
 		channelResultSet.waitSignalMutex.Unlock()
 
 		// Technically if we assigned channelResultSet.waitSignal then unlocked, it could be set to nil or
 		// overwritten to another channel before we check it, so to be safe, create additional variable and
 		// check that instead.
-		<-waitSignal
-		return channelResultSet.IsEmpty()
+		select {
+		case <-waitSignal:
+			return channelResultSet.isEmptyContext(ctx)
+		case <-ctx.Done():
+			channelResultSet.cancel(ctx.Err())
+			return true, ctx.Err()
+		}
 	}
 }
 
@@ -113,27 +141,104 @@ func (channelResultSet *channelResultSet) IsEmpty() bool {
 func (channelResultSet *channelResultSet) Close() {
 	if !channelResultSet.closed {
 		channelResultSet.channelMutex.Lock()
+		if channelResultSet.closed {
+			// Cancelled concurrently.
+			channelResultSet.channelMutex.Unlock()
+			return
+		}
 		channelResultSet.closed = true
 		channelResultSet.container.delete(channelResultSet.requestID)
 		close(channelResultSet.channel)
 		channelResultSet.channelMutex.Unlock()
+		channelResultSet.closeDone()
 		channelResultSet.sendSignal()
+		channelResultSet.complete()
 	}
 }
 
 // Close and remove from the channelResultSet from the container without locking container. Meant for use when calling
-// function already locks the container.
+// function already locks the container, which must call complete once it has released the lock, so that callbacks
+// registered with OnComplete can use the container.
 func (channelResultSet *channelResultSet) unlockedClose() {
 	if !channelResultSet.closed {
 		channelResultSet.channelMutex.Lock()
+		if channelResultSet.closed {
+			// Cancelled concurrently.
+			channelResultSet.channelMutex.Unlock()
+			return
+		}
 		channelResultSet.closed = true
 		delete(channelResultSet.container.internalMap, channelResultSet.requestID)
 		close(channelResultSet.channel)
 		channelResultSet.channelMutex.Unlock()
+		channelResultSet.closeDone()
 		channelResultSet.sendSignal()
 	}
 }
 
+// cancel stops the channelResultSet from receiving any further results. It is removed from its container, which
+// discards any response that still arrives for it, and err is returned to anyone reading it.
+func (channelResultSet *channelResultSet) cancel(err error) {
+	channelResultSet.container.cancel(channelResultSet.requestID)
+	// Release addResult if it is blocked on a full channel before grabbing the channel mutex.
+	channelResultSet.closeDone()
+	channelResultSet.channelMutex.Lock()
+	if !channelResultSet.closed {
+		channelResultSet.err = err
+		channelResultSet.closed = true
+		close(channelResultSet.channel)
+	}
+	channelResultSet.channelMutex.Unlock()
+	channelResultSet.sendSignal()
+	channelResultSet.complete()
+}
+
+// OnComplete registers callback to be called once the channelResultSet has received all results, failed or was
+// cancelled, at which point GetError and GetStatusAttributes return the outcome of the request. If it is already
+// complete, callback is called immediately. Callbacks are called on the goroutine reading responses and should not
+// block.
+func (channelResultSet *channelResultSet) OnComplete(callback func()) {
+	channelResultSet.channelMutex.Lock()
+	if channelResultSet.closed {
+		channelResultSet.channelMutex.Unlock()
+		callback()
+		return
+	}
+	channelResultSet.onComplete = append(channelResultSet.onComplete, callback)
+	channelResultSet.channelMutex.Unlock()
+}
+
+// complete calls the callbacks registered with OnComplete once the channelResultSet is closed.
+func (channelResultSet *channelResultSet) complete() {
+	channelResultSet.channelMutex.Lock()
+	callbacks := channelResultSet.onComplete
+	channelResultSet.onComplete = nil
+	channelResultSet.channelMutex.Unlock()
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+func (channelResultSet *channelResultSet) closeDone() {
+	channelResultSet.doneOnce.Do(func() {
+		close(channelResultSet.done)
+	})
+}
+
+// bindContext cancels the channelResultSet when ctx is done before all results have been received.
+func (channelResultSet *channelResultSet) bindContext(ctx context.Context) {
+	if ctx.Done() == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			channelResultSet.cancel(ctx.Err())
+		case <-channelResultSet.done:
+		}
+	}()
+}
+
 func (channelResultSet *channelResultSet) setAggregateTo(val string) {
 	channelResultSet.aggregateTo = val
 }
@@ -176,6 +281,24 @@ func (channelResultSet *channelResultSet) One() (*Result, bool, error) {
 	return result, ok, nil
 }
 
+// OneContext is One, except that it stops waiting once ctx is done. In that case the channelResultSet is cancelled and
+// ctx.Err() is returned.
+func (channelResultSet *channelResultSet) OneContext(ctx context.Context) (*Result, bool, error) {
+	if channelResultSet.err != nil {
+		return nil, false, channelResultSet.err
+	}
+	select {
+	case result, ok := <-channelResultSet.channel:
+		if channelResultSet.err != nil {
+			return nil, false, channelResultSet.err
+		}
+		return result, ok, nil
+	case <-ctx.Done():
+		channelResultSet.cancel(ctx.Err())
+		return nil, false, ctx.Err()
+	}
+}
+
 // All returns all remaining results for the channelResultSet (results grabbed through One will not be present).
 func (channelResultSet *channelResultSet) All() ([]*Result, error) {
 	var results []*Result
@@ -185,27 +308,118 @@ func (channelResultSet *channelResultSet) All() ([]*Result, error) {
 	return results, channelResultSet.err
 }
 
+// AllContext is All, except that it stops waiting once ctx is done. In that case the channelResultSet is cancelled and
+// ctx.Err() is returned.
+func (channelResultSet *channelResultSet) AllContext(ctx context.Context) ([]*Result, error) {
+	var results []*Result
+	for {
+		select {
+		case result, ok := <-channelResultSet.channel:
+			if !ok {
+				return results, channelResultSet.err
+			}
+			results = append(results, result)
+		case <-ctx.Done():
+			channelResultSet.cancel(ctx.Err())
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Iter returns an iterator over the remaining results of the channelResultSet, which yields each result as it is
+// received. A failure of the request is yielded as the last error. Stopping the iteration early cancels the
+// channelResultSet, discarding its remaining results.
+func (channelResultSet *channelResultSet) Iter() Seq[*Result] {
+	return channelResultSet.IterContext(context.Background())
+}
+
+// IterContext is Iter, except that it stops waiting once ctx is done. In that case the channelResultSet is cancelled
+// and ctx.Err() is yielded.
+func (channelResultSet *channelResultSet) IterContext(ctx context.Context) Seq[*Result] {
+	return func(yield func(*Result, error) bool) {
+		for {
+			select {
+			case result, ok := <-channelResultSet.channel:
+				if !ok {
+					if channelResultSet.err != nil {
+						yield(nil, channelResultSet.err)
+					}
+					return
+				}
+				if !yield(result, nil) {
+					channelResultSet.cancel(nil)
+					return
+				}
+			case <-ctx.Done():
+				channelResultSet.cancel(ctx.Err())
+				yield(nil, ctx.Err())
+				return
+			}
+		}
+	}
+}
+
+// DecodeAll waits for all remaining results of the channelResultSet and appends them to the slice pointed to by dst,
+// decoding each of them as described by Result.Decode.
+func (channelResultSet *channelResultSet) DecodeAll(dst interface{}) error {
+	results, err := channelResultSet.All()
+	if err != nil {
+		return err
+	}
+	return decodeAll(results, dst)
+}
+
 func (channelResultSet *channelResultSet) addResult(r *Result) {
 	channelResultSet.channelMutex.Lock()
+	// A cancelled channelResultSet is already closed and must not be written to.
+	if channelResultSet.closed {
+		channelResultSet.channelMutex.Unlock()
+		return
+	}
 	if r.GetType().Kind() == reflect.Array || r.GetType().Kind() == reflect.Slice {
+	results:
 		for _, v := range r.Data.([]interface{}) {
-			if reflect.TypeOf(v) == reflect.TypeOf(&Traverser{}) {
-				for i := int64(0); i < (v.(*Traverser)).bulk; i++ {
-					channelResultSet.channel <- &Result{(v.(*Traverser)).value}
+			if channelResultSet.preserveBulk {
+				if !channelResultSet.send(&Result{v}) {
+					break results
+				}
+			} else if traverser, ok := v.(*Traverser); ok {
+				value := expandBulkSets(traverser.value)
+				for i := int64(0); i < traverser.bulk; i++ {
+					if !channelResultSet.send(&Result{value}) {
+						break results
+					}
 				}
-			} else {
-				channelResultSet.channel <- &Result{v}
+			} else if !channelResultSet.send(&Result{expandBulkSets(v)}) {
+				break results
 			}
 		}
+	} else if channelResultSet.preserveBulk {
+		channelResultSet.send(&Result{r.Data})
 	} else {
-		channelResultSet.channel <- &Result{r.Data}
+		channelResultSet.send(&Result{expandBulkSets(r.Data)})
 	}
 	channelResultSet.channelMutex.Unlock()
 	channelResultSet.sendSignal()
 }
 
+// send pushes a Result onto the channel, giving up if the channelResultSet is cancelled while the channel is full.
+func (channelResultSet *channelResultSet) send(r *Result) bool {
+	select {
+	case channelResultSet.channel <- r:
+		return true
+	case <-channelResultSet.done:
+		return false
+	}
+}
+
 func newChannelResultSetCapacity(requestID string, container *synchronizedMap, channelSize int) ResultSet {
-	return &channelResultSet{make(chan *Result, channelSize), requestID, container, "", nil, false, nil, nil, sync.Mutex{}, sync.Mutex{}}
+	return &channelResultSet{
+		channel:   make(chan *Result, channelSize),
+		requestID: requestID,
+		container: container,
+		done:      make(chan struct{}),
+	}
 }
 
 func newChannelResultSet(requestID string, container *synchronizedMap) ResultSet {
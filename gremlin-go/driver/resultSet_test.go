@@ -20,6 +20,7 @@ under the License.
 package gremlingo
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -30,8 +31,8 @@ import (
 
 func getSyncMap() *synchronizedMap {
 	return &synchronizedMap{
-		make(map[string]ResultSet),
-		sync.Mutex{},
+		internalMap: make(map[string]ResultSet),
+		syncLock:    sync.Mutex{},
 	}
 }
 
@@ -51,6 +52,34 @@ func TestChannelResultSet(t *testing.T) {
 		assert.Equal(t, r.GetAggregateTo(), testAggregateTo)
 	})
 
+	t.Run("Test ResultSet DecodeAll.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		go func() {
+			channelResultSet.addResult(&Result{[]interface{}{
+				map[interface{}]interface{}{"name": []interface{}{"marko"}, "age": []interface{}{int32(29)}},
+				map[interface{}]interface{}{"name": []interface{}{"vadas"}, "age": []interface{}{int32(27)}},
+			}})
+			channelResultSet.Close()
+		}()
+		var people []struct {
+			Name string `gremlin:"name"`
+			Age  int    `gremlin:"age"`
+		}
+		assert.Nil(t, channelResultSet.DecodeAll(&people))
+		assert.Len(t, people, 2)
+		assert.Equal(t, "vadas", people[1].Name)
+		assert.Equal(t, 27, people[1].Age)
+	})
+
+	t.Run("Test ResultSet DecodeAll error.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		channelResultSet.setError(newError(E0502ResponseHandlerReadLoopError, "failed", 500))
+		channelResultSet.Close()
+		var names []string
+		err := channelResultSet.DecodeAll(&names)
+		assert.True(t, isSameErrorCode(newError(E0502ResponseHandlerReadLoopError), err))
+	})
+
 	t.Run("Test ResultSet close.", func(t *testing.T) {
 		channelResultSet := newChannelResultSet(mockID, getSyncMap())
 		assert.NotPanics(t, func() { channelResultSet.Close() })
@@ -154,6 +183,165 @@ func TestChannelResultSet(t *testing.T) {
 		channelResultSet.Close()
 		assert.Equal(t, 0, container.size())
 	})
+
+	t.Run("Test ResultSet AllContext.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		AddResults(channelResultSet, 10)
+		go closeAfterTime(500, channelResultSet)
+		results, err := channelResultSet.AllContext(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 10, len(results))
+	})
+
+	t.Run("Test ResultSet AllContext cancelled.", func(t *testing.T) {
+		container := getSyncMap()
+		channelResultSet := newChannelResultSet(mockID, container)
+		container.store(mockID, channelResultSet)
+		AddResults(channelResultSet, 10)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		results, err := channelResultSet.AllContext(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Nil(t, results)
+		assert.Equal(t, 0, container.size())
+		assert.True(t, container.discard(mockID, true))
+		assert.False(t, container.discard(mockID, true))
+		assert.Equal(t, context.DeadlineExceeded, channelResultSet.GetError())
+		// Adding results after cancellation must not panic or block.
+		assert.NotPanics(t, func() { AddResults(channelResultSet, 10) })
+		assert.NotPanics(t, func() { channelResultSet.Close() })
+	})
+
+	t.Run("Test ResultSet OneContext cancelled.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+		}()
+		result, ok, err := channelResultSet.OneContext(ctx)
+		assert.Equal(t, context.Canceled, err)
+		assert.False(t, ok)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Test ResultSet isEmptyContext cancelled.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := channelResultSet.isEmptyContext(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.True(t, channelResultSet.IsEmpty())
+	})
+
+	t.Run("Test ResultSet cancel unblocks full channel.", func(t *testing.T) {
+		channelResultSet := newChannelResultSetCapacity(mockID, getSyncMap(), 1)
+		done := make(chan bool)
+		go func() {
+			channelResultSet.addResult(&Result{[]interface{}{1, 2, 3}})
+			done <- true
+		}()
+		time.Sleep(100 * time.Millisecond)
+		channelResultSet.cancel(context.Canceled)
+		select {
+		case <-done:
+		case <-time.After(1 * time.Second):
+			t.Fatal("addResult is still blocked after cancel")
+		}
+	})
+
+	t.Run("Test ResultSet bindContext.", func(t *testing.T) {
+		container := getSyncMap()
+		channelResultSet := newChannelResultSet(mockID, container)
+		container.store(mockID, channelResultSet)
+		ctx, cancel := context.WithCancel(context.Background())
+		channelResultSet.bindContext(ctx)
+		cancel()
+		results, err := channelResultSet.All()
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, results)
+		assert.Equal(t, 0, container.size())
+	})
+
+	t.Run("Test ResultSet OnComplete.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		calls := 0
+		channelResultSet.OnComplete(func() { calls++ })
+		AddResults(channelResultSet, 2)
+		assert.Equal(t, 0, calls)
+		channelResultSet.Close()
+		assert.Equal(t, 1, calls)
+		channelResultSet.Close()
+		assert.Equal(t, 1, calls)
+		channelResultSet.OnComplete(func() { calls++ })
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Test ResultSet expands bulk.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		bulkSet := NewBulkSet()
+		bulkSet.Add("a", 2)
+		channelResultSet.addResult(&Result{[]interface{}{&Traverser{bulk: 2, value: "v"}, bulkSet,
+			map[interface{}]interface{}{"x": &Traverser{bulk: 1, value: "t"}}}})
+		channelResultSet.Close()
+		results, err := channelResultSet.All()
+		assert.Nil(t, err)
+		assert.Len(t, results, 4)
+		assert.Equal(t, "v", results[0].Data)
+		assert.Equal(t, "v", results[1].Data)
+		assert.Equal(t, []interface{}{"a", "a"}, results[2].Data)
+		// Traversers are only expanded at the top level of a response.
+		assert.Equal(t, map[interface{}]interface{}{"x": &Traverser{bulk: 1, value: "t"}}, results[3].Data)
+	})
+
+	t.Run("Test ResultSet preserves bulk.", func(t *testing.T) {
+		resultSet := newChannelResultSet(mockID, getSyncMap())
+		resultSet.(*channelResultSet).preserveBulk = true
+		bulkSet := NewBulkSet()
+		bulkSet.Add("a", 1000000000)
+		resultSet.addResult(&Result{[]interface{}{&Traverser{bulk: 2, value: "v"}, bulkSet}})
+		resultSet.Close()
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Len(t, results, 2)
+		traverser, err := results[0].GetTraverser()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), traverser.Bulk())
+		assert.Equal(t, int64(1000000000), results[1].Data.(*BulkSet).Size())
+	})
+
+	t.Run("Test ResultSet OnComplete cancelled.", func(t *testing.T) {
+		channelResultSet := newChannelResultSet(mockID, getSyncMap())
+		var err error
+		channelResultSet.OnComplete(func() { err = channelResultSet.GetError() })
+		channelResultSet.cancel(context.Canceled)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("Test ResultSet OnComplete after container closeAll.", func(t *testing.T) {
+		container := getSyncMap()
+		channelResultSet := newChannelResultSet(mockID, container)
+		container.store(mockID, channelResultSet)
+		var err error
+		channelResultSet.OnComplete(func() {
+			err = channelResultSet.GetError()
+			// Submitting a request from the callback stores a new ResultSet in the container.
+			container.store("retry", newChannelResultSet("retry", container))
+		})
+		done := make(chan struct{})
+		go func() {
+			container.closeAll(context.Canceled)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("closeAll did not return")
+		}
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, container.size())
+		assert.NotNil(t, container.load("retry"))
+	})
 }
 
 func AddResultsPause(resultSet ResultSet, count int, ticks time.Duration) {
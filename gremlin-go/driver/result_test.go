@@ -379,4 +379,26 @@ func TestResult(t *testing.T) {
 		res := r.IsNil()
 		assert.True(t, res)
 	})
+
+	t.Run("Test Result.GetTraverser()", func(t *testing.T) {
+		traverser := &Traverser{bulk: 3, value: "marko"}
+		r := Result{traverser}
+		res, err := r.GetTraverser()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), res.Bulk())
+		assert.Equal(t, "marko", res.Value())
+		_, err = (&Result{"marko"}).GetTraverser()
+		assert.True(t, isSameErrorCode(newError(E0607ResultNotTraverserError), err))
+	})
+
+	t.Run("Test Result.GetBulkSet()", func(t *testing.T) {
+		bulkSet := NewBulkSet()
+		bulkSet.Add("marko", 2)
+		r := Result{bulkSet}
+		res, err := r.GetBulkSet()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), res.Count("marko"))
+		_, err = (&Result{[]interface{}{"marko", "marko"}}).GetBulkSet()
+		assert.True(t, isSameErrorCode(newError(E0609ResultNotBulkSetError), err))
+	})
 }
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// retryOption is the key with which traversals opt in to retries, as in g.With("retry", true).
+const retryOption = "retry"
+
+// mutatingSteps are the steps which prevent a traversal from being retried, as retrying them could apply them twice.
+var mutatingSteps = map[string]bool{
+	"addV":     true,
+	"addE":     true,
+	"drop":     true,
+	"property": true,
+	"mergeV":   true,
+	"mergeE":   true,
+}
+
+// RetryPolicy configures how requests that opted in to retries are sent again after a transient failure. Scripts opt
+// in with RequestOptionsBuilder.SetRetry and traversals with g.With("retry", true). A request is only retried as long
+// as no result has been received for it, and never in a session. Traversals with mutating steps are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first attempt.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is the factor by which the delay grows after each retry.
+	BackoffMultiplier float64
+	// Jitter is the fraction of the delay by which it is randomly varied.
+	Jitter float64
+	// Retryable decides whether a failed attempt is retried. DefaultRetryable is used if nil.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy making up to 3 attempts, waiting 100 milliseconds before the first retry
+// and twice as long before each further retry, and retrying the errors classified by DefaultRetryable.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+	}
+}
+
+// DefaultRetryable classifies the following errors as transient: errors of the transport layer such as a connection
+// dropping while reading, failures to obtain a connection from the pool, and ResponseError with the status codes 429
+// (Too Many Requests) and 596 (Temporary Server Error) by which the server reports being overloaded. Any other error,
+// such as failing to serialize the request, is raised again by every attempt and is not retried.
+func DefaultRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var responseError *ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode == 429 || responseError.StatusCode == 596
+	}
+	return isConnectionError(err)
+}
+
+func (policy *RetryPolicy) retryable(err error) bool {
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+	return DefaultRetryable(err)
+}
+
+func (policy *RetryPolicy) backoff() *backoff {
+	return &backoff{
+		initial:    policy.InitialBackoff,
+		max:        policy.MaxBackoff,
+		multiplier: policy.BackoffMultiplier,
+		jitter:     policy.Jitter,
+	}
+}
+
+// isRetryableBytecode reports whether the bytecode opted in to retries and has no mutating steps.
+func isRetryableBytecode(bytecode *Bytecode) bool {
+	retry := false
+	for _, insn := range bytecode.sourceInstructions {
+		switch insn.operator {
+		case "withStrategies":
+			for _, strategyInterface := range insn.arguments {
+				strategy, ok := strategyInterface.(*traversalStrategy)
+				if ok && strategy.name == decorationNamespace+"OptionsStrategy" {
+					if value, ok := strategy.configuration[retryOption].(bool); ok {
+						retry = value
+					}
+				}
+			}
+		case "with":
+			if len(insn.arguments) == 2 && insn.arguments[0] == retryOption {
+				if value, ok := insn.arguments[1].(bool); ok {
+					retry = value
+				}
+			}
+		}
+	}
+	return retry && !hasMutatingStep(bytecode)
+}
+
+// hasMutatingStep reports whether the bytecode or any child traversal of it has a mutating step.
+func hasMutatingStep(bytecode *Bytecode) bool {
+	for _, insn := range bytecode.stepInstructions {
+		if mutatingSteps[insn.operator] {
+			return true
+		}
+		for _, argument := range insn.arguments {
+			if argumentHasMutatingStep(argument) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func argumentHasMutatingStep(argument interface{}) bool {
+	switch typedArgument := argument.(type) {
+	case *Bytecode:
+		return hasMutatingStep(typedArgument)
+	case Bytecode:
+		return hasMutatingStep(&typedArgument)
+	case *GraphTraversal:
+		return hasMutatingStep(typedArgument.Bytecode)
+	case []interface{}:
+		for _, element := range typedArgument {
+			if argumentHasMutatingStep(element) {
+				return true
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, value := range typedArgument {
+			if argumentHasMutatingStep(key) || argumentHasMutatingStep(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// scriptedPool is a connectionPool which answers each write with the next of its outcomes.
+type scriptedPool struct {
+	lock     sync.Mutex
+	outcomes []func(ResultSet) error
+	written  int
+}
+
+func (pool *scriptedPool) write(request *request) (ResultSet, error) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	outcome := pool.outcomes[pool.written]
+	pool.written++
+	resultSet := newChannelResultSet(request.requestID.String(), &synchronizedMap{internalMap: map[string]ResultSet{}})
+	if err := outcome(resultSet); err != nil {
+		return nil, err
+	}
+	return resultSet, nil
+}
+
+func (pool *scriptedPool) close() {}
+
+func (pool *scriptedPool) activeResults() int {
+	return 0
+}
+
+func (pool *scriptedPool) healthy() bool {
+	return true
+}
+
+func (pool *scriptedPool) writtenCount() int {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+	return pool.written
+}
+
+func respondWith(data interface{}) func(ResultSet) error {
+	return func(resultSet ResultSet) error {
+		resultSet.addResult(&Result{data})
+		resultSet.Close()
+		return nil
+	}
+}
+
+func failWith(err error) func(ResultSet) error {
+	return func(resultSet ResultSet) error {
+		resultSet.setError(err)
+		resultSet.Close()
+		return nil
+	}
+}
+
+func failWrite(err error) func(ResultSet) error {
+	return func(ResultSet) error {
+		return err
+	}
+}
+
+func newRetryTestClient(policy *RetryPolicy, outcomes ...func(ResultSet) error) (*Client, *scriptedPool) {
+	pool := &scriptedPool{outcomes: outcomes}
+	client := &Client{
+		traversalSource: "g",
+		logHandler:      newLogHandler(&defaultLogger{}, Error, language.English),
+		connections:     pool,
+		retryPolicy:     policy,
+	}
+	return client, pool
+}
+
+func fastRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	return policy
+}
+
+func TestRetryPolicy(t *testing.T) {
+	overloaded := &ResponseError{StatusCode: 596, StatusMessage: "overloaded"}
+	dropped := &connectionError{io.ErrUnexpectedEOF}
+
+	t.Run("Test DefaultRetryable", func(t *testing.T) {
+		assert.False(t, DefaultRetryable(nil))
+		assert.False(t, DefaultRetryable(context.Canceled))
+		assert.False(t, DefaultRetryable(context.DeadlineExceeded))
+		assert.True(t, DefaultRetryable(overloaded))
+		assert.True(t, DefaultRetryable(&ResponseError{StatusCode: 429}))
+		assert.False(t, DefaultRetryable(&ResponseError{StatusCode: 597}))
+		assert.False(t, DefaultRetryable(&ResponseError{StatusCode: 500}))
+		assert.True(t, DefaultRetryable(newError(E0105ConnectionPoolFullButNoneValid)))
+		assert.True(t, DefaultRetryable(newError(E0106ConnectionPoolNoHostAvailableError)))
+		assert.False(t, DefaultRetryable(newError(E0103ConnectionPoolClosedError)))
+		assert.True(t, DefaultRetryable(dropped))
+		assert.True(t, DefaultRetryable(fmt.Errorf("write failed: %w", dropped)))
+		assert.False(t, DefaultRetryable(io.ErrUnexpectedEOF))
+		assert.False(t, DefaultRetryable(newError(E0407GetSerializerToWriteUnknownTypeError, "struct {}")))
+		assert.False(t, DefaultRetryable(newError(E0502ResponseHandlerReadLoopError)))
+	})
+
+	t.Run("Test isRetryableBytecode", func(t *testing.T) {
+		g := NewDefaultGraphTraversalSource()
+		assert.False(t, isRetryableBytecode(g.V().Count().Bytecode))
+		assert.True(t, isRetryableBytecode(g.With(retryOption, true).V().Count().Bytecode))
+		assert.False(t, isRetryableBytecode(g.With(retryOption, false).V().Count().Bytecode))
+		assert.True(t, isRetryableBytecode(g.WithStrategies(OptionsStrategy(map[string]interface{}{retryOption: true})).V().Bytecode))
+		assert.False(t, isRetryableBytecode(g.With(retryOption, true).AddV("person").Bytecode))
+		assert.False(t, isRetryableBytecode(g.With(retryOption, true).V().Property("name", "marko").Bytecode))
+		assert.False(t, isRetryableBytecode(g.With(retryOption, true).V().Union(T__.Out(), T__.Drop()).Bytecode))
+		assert.False(t, isRetryableBytecode(g.With(retryOption, true).V().Coalesce(T__.Out(), T__.Local(T__.AddE("knows"))).Bytecode))
+	})
+
+	t.Run("Test retries script until success", func(t *testing.T) {
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(overloaded), failWrite(dropped), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		results, err := resultSet.All()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), results[0].Data)
+		assert.Equal(t, 3, pool.writtenCount())
+	})
+
+	t.Run("Test does not retry script without opt in", func(t *testing.T) {
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(overloaded), respondWith(int64(1)))
+		resultSet, err := client.Submit("g.V().count()")
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.True(t, errors.Is(err, overloaded))
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test does not retry without policy", func(t *testing.T) {
+		client, pool := newRetryTestClient(nil, failWith(overloaded), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test does not retry non retryable error", func(t *testing.T) {
+		invalid := &ResponseError{StatusCode: 597, StatusMessage: "invalid"}
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(invalid), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V(", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.True(t, errors.Is(err, invalid))
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test does not retry serialization error", func(t *testing.T) {
+		unserializable := newError(E0407GetSerializerToWriteUnknownTypeError, "struct {}")
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWrite(unserializable), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, resultSet)
+		assert.True(t, isSameErrorCode(unserializable, err))
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test returns last error after max attempts", func(t *testing.T) {
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(overloaded), failWith(overloaded), failWith(overloaded), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.True(t, errors.Is(err, overloaded))
+		assert.Equal(t, 3, pool.writtenCount())
+
+		client, pool = newRetryTestClient(fastRetryPolicy(), failWrite(dropped), failWrite(dropped), failWrite(dropped))
+		resultSet, err = client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, resultSet)
+		assert.Equal(t, dropped, err)
+		assert.Equal(t, 3, pool.writtenCount())
+	})
+
+	t.Run("Test custom Retryable", func(t *testing.T) {
+		policy := fastRetryPolicy()
+		policy.Retryable = func(err error) bool {
+			return false
+		}
+		client, pool := newRetryTestClient(policy, failWith(overloaded), respondWith(int64(1)))
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test does not retry in session", func(t *testing.T) {
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(overloaded), respondWith(int64(1)))
+		client.session = "session"
+		resultSet, err := client.SubmitWithOptions("g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.Nil(t, err)
+		_, err = resultSet.All()
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test stops retrying when context is done", func(t *testing.T) {
+		policy := fastRetryPolicy()
+		policy.InitialBackoff = time.Minute
+		client, pool := newRetryTestClient(policy, failWith(overloaded), respondWith(int64(1)))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := client.SubmitWithOptionsContext(ctx, "g.V().count()", new(RequestOptionsBuilder).SetRetry(true).Create())
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+
+	t.Run("Test retries traversal which opted in", func(t *testing.T) {
+		client, pool := newRetryTestClient(fastRetryPolicy(), failWith(overloaded), respondWith(int64(1)))
+		g := Traversal_().WithRemote(&DriverRemoteConnection{client: client})
+		count, err := g.With(retryOption, true).V().Count().Next()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), count.Data)
+		assert.Equal(t, 2, pool.writtenCount())
+
+		client, pool = newRetryTestClient(fastRetryPolicy(), failWith(overloaded), respondWith(int64(1)))
+		g = Traversal_().WithRemote(&DriverRemoteConnection{client: client})
+		_, err = g.With(retryOption, true).AddV("person").Next()
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, pool.writtenCount())
+	})
+}
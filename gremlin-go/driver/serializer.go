@@ -22,19 +22,51 @@ package gremlingo
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 	"math/big"
 	"reflect"
 	"strings"
 
+	"github.com/apache/tinkerpop/gremlin-go/v3/driver/internal/wire"
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
 )
 
 const graphBinaryMimeType = "application/vnd.graphbinary-v1.0"
 
+// SerializerType selects the format in which requests and responses are serialized.
+type SerializerType int
+
+const (
+	// GraphBinaryV1 serializes messages with GraphBinary 1.0: application/vnd.graphbinary-v1.0
+	GraphBinaryV1 SerializerType = iota + 1
+	// GraphSONV3 serializes messages with GraphSON 3.0: application/vnd.gremlin-v3.0+json
+	GraphSONV3
+)
+
+// mimeType returns the mime type of the format.
+func (serializerType SerializerType) mimeType() string {
+	if serializerType == GraphSONV3 {
+		return graphSONV3MimeType
+	}
+	return graphBinaryMimeType
+}
+
+func newSerializer(serializerType SerializerType, handler *logHandler) (serializer, error) {
+	switch serializerType {
+	case GraphBinaryV1:
+		return newGraphBinarySerializer(handler), nil
+	case GraphSONV3:
+		return newGraphSONSerializer(handler), nil
+	default:
+		return nil, newError(E0705UnknownSerializerTypeError, serializerType)
+	}
+}
+
 // serializer interface for serializers.
 type serializer interface {
 	serializeMessage(request *request) ([]byte, error)
-	deserializeMessage(message []byte) (response, error)
+	deserializeMessage(message io.Reader) (response, error)
 }
 
 // graphBinarySerializer serializes/deserializes message to/from GraphBinary.
@@ -43,14 +75,25 @@ type graphBinarySerializer struct {
 }
 
 type writer func(interface{}, *bytes.Buffer, *graphBinaryTypeSerializer) ([]byte, error)
-type reader func(data *[]byte, i *int) (interface{}, error)
+type reader func(d *graphBinaryDecoder) (interface{}, error)
 
 var deserializers map[dataType]reader
 var serializers map[dataType]writer
 
+// requestDeserializers read the types which only clients write. They are read from requests, but not from responses.
+var requestDeserializers map[dataType]reader
+
 func init() {
 	initSerializers()
 	initDeserializers()
+	initRequestDeserializers()
+	initGraphSONReaders()
+
+	wire.ReadGraphBinaryRequest = readGraphBinaryRequest
+	wire.WriteGraphBinaryResponse = writeGraphBinaryResponse
+	wire.ReadGraphBinaryResponse = readGraphBinaryResponse
+	wire.MarshalGraphBinary = marshalGraphBinary
+	wire.UnmarshalGraphBinary = unmarshalGraphBinary
 }
 
 func newGraphBinarySerializer(handler *logHandler) serializer {
@@ -83,7 +126,7 @@ func convertArgs(request *request, gs graphBinarySerializer) (map[string]interfa
 			typeName = reflect.TypeOf(gremlin).Name()
 		}
 
-		return nil, newError(err0704ConvertArgsNoSerializerError, typeName)
+		return nil, newError(E0704ConvertArgsNoSerializerError, typeName)
 	}
 }
 
@@ -176,44 +219,55 @@ func uuidToBigInt(requestID uuid.UUID) big.Int {
 	return bigInt
 }
 
-// deserializeMessage deserializes a response message.
-func (gs graphBinarySerializer) deserializeMessage(message []byte) (response, error) {
+// deserializeMessage deserializes a response message, which is decoded while it is read from message.
+func (gs graphBinarySerializer) deserializeMessage(message io.Reader) (response, error) {
 	var msg response
 
-	if message == nil || len(message) == 0 {
+	d := newGraphBinaryDecoder(message)
+	defer d.release()
+	if _, err := d.reader.Peek(1); err == io.EOF {
 		gs.ser.logHandler.log(Error, nullInput)
-		return msg, newError(err0405ReadValueInvalidNullInputError)
+		return msg, newError(E0405ReadValueInvalidNullInputError)
 	}
 
 	// Skip version and nullable byte.
-	i := 2
-	id, err := readUuid(&message, &i)
+	if err := d.skip(2); err != nil {
+		return msg, d.failure(err, byteType)
+	}
+	id, err := readUuid(d)
 	if err != nil {
-		return msg, err
+		return msg, d.failure(err, uuidType)
 	}
 	msg.responseID = id.(uuid.UUID)
-	msg.responseStatus.code = uint16(readUint32Safe(&message, &i) & 0xFF)
-	isMessageValid := readByteSafe(&message, &i)
+	code, err := readUint32Safe(d)
+	if err != nil {
+		return msg, d.failure(err, intType)
+	}
+	msg.responseStatus.code = uint16(code)
+	isMessageValid, err := readByteSafe(d)
+	if err != nil {
+		return msg, d.failure(err, booleanType)
+	}
 	if isMessageValid == 0 {
-		message, err := readString(&message, &i)
+		message, err := readString(d)
 		if err != nil {
-			return msg, err
+			return msg, d.failure(err, stringType)
 		}
 		msg.responseStatus.message = message.(string)
 	}
-	attr, err := readMapUnqualified(&message, &i)
+	attr, err := readMapUnqualified(d)
 	if err != nil {
-		return msg, err
+		return msg, d.failure(err, mapType)
 	}
 	msg.responseStatus.attributes = attr.(map[string]interface{})
-	meta, err := readMapUnqualified(&message, &i)
+	meta, err := readMapUnqualified(d)
 	if err != nil {
-		return msg, err
+		return msg, d.failure(err, mapType)
 	}
 	msg.responseResult.meta = meta.(map[string]interface{})
-	msg.responseResult.data, err = readFullyQualifiedNullable(&message, &i, true)
+	msg.responseResult.data, err = readFullyQualifiedNullable(d, true)
 	if err != nil {
-		return msg, err
+		return msg, d.failure(err, nullType)
 	}
 	return msg, nil
 }
@@ -257,6 +311,20 @@ func initSerializers() {
 		setType:               setWriter,
 		dateType:              timeWriter,
 		durationType:          durationWriter,
+		charType:              charWriter,
+		inetAddressType:       inetAddressWriter,
+		instantType:           instantWriter,
+		localDateType:         localDateWriter,
+		localDateTimeType:     localDateTimeWriter,
+		localTimeType:         localTimeWriter,
+		monthDayType:          monthDayWriter,
+		offsetDateTimeType:    offsetDateTimeWriter,
+		offsetTimeType:        offsetTimeWriter,
+		periodType:            periodWriter,
+		yearType:              yearWriter,
+		yearMonthType:         yearMonthWriter,
+		zonedDateTimeType:     zonedDateTimeWriter,
+		zoneOffsetType:        zoneOffsetWriter,
 		cardinalityType:       enumWriter,
 		columnType:            enumWriter,
 		directionType:         enumWriter,
@@ -274,10 +342,25 @@ func initSerializers() {
 		mapType:               mapWriter,
 		listType:              listWriter,
 		byteBuffer:            byteBufferWriter,
+		customType:            customTypeWriter,
+		treeType:              treeWriter,
+		graphType:             graphWriter,
+		bulkSetType:           bulkSetWriter,
+		traverserType:         traverserWriter,
 		classType:             classWriter,
 	}
 }
 
+func initRequestDeserializers() {
+	requestDeserializers = map[dataType]reader{
+		bytecodeType:          bytecodeReader,
+		pType:                 pReader,
+		textPType:             textPReader,
+		lambdaType:            lambdaReader,
+		traversalStrategyType: traversalStrategyReader,
+	}
+}
+
 func initDeserializers() {
 	deserializers = map[dataType]reader{
 		// Primitive
@@ -305,6 +388,22 @@ func initDeserializers() {
 		timestampType: timeReader,
 		durationType:  durationReader,
 
+		// Extended
+		charType:           readChar,
+		inetAddressType:    readInetAddress,
+		instantType:        readInstant,
+		localDateType:      readLocalDate,
+		localDateTimeType:  readLocalDateTime,
+		localTimeType:      readLocalTime,
+		monthDayType:       readMonthDay,
+		offsetDateTimeType: readOffsetDateTime,
+		offsetTimeType:     readOffsetTime,
+		periodType:         readPeriod,
+		yearType:           readYear,
+		yearMonthType:      readYearMonth,
+		zonedDateTimeType:  readOffsetDateTime,
+		zoneOffsetType:     readZoneOffset,
+
 		// Graph
 		traverserType:      traverserReader,
 		vertexType:         vertexReader,
@@ -313,12 +412,141 @@ func initDeserializers() {
 		vertexPropertyType: vertexPropertyReader,
 		pathType:           pathReader,
 		bulkSetType:        bulkSetReader,
+		treeType:           treeReader,
+		graphType:          graphReader,
 		tType:              enumReader,
 		directionType:      enumReader,
 		bindingType:        bindingReader,
 
+		// Process
+		barrierType:     enumReader,
+		cardinalityType: enumReader,
+		columnType:      enumReader,
+		mergeType:       enumReader,
+		operatorType:    enumReader,
+		orderType:       enumReader,
+		pickType:        enumReader,
+		popType:         enumReader,
+		scopeType:       enumReader,
+
 		// Metrics
 		metricsType:          metricsReader,
 		traversalMetricsType: traversalMetricsReader,
 	}
 }
+
+func newStandaloneTypeSerializer() *graphBinaryTypeSerializer {
+	return &graphBinaryTypeSerializer{newLogHandler(&defaultLogger{}, Off, language.English)}
+}
+
+// readGraphBinaryRequest deserializes a request message written in GraphBinary by a Client or a
+// DriverRemoteConnection. Together with writeGraphBinaryResponse it allows to implement stand-ins for Gremlin Server,
+// such as the one of the gremlintest package.
+func readGraphBinaryRequest(message []byte) (*wire.RequestMessage, error) {
+	if len(message) == 0 {
+		return nil, newError(E0405ReadValueInvalidNullInputError)
+	}
+
+	d := newGraphBinaryDecoder(bytes.NewReader(message))
+	defer d.release()
+	d.request = true
+	// Skip mime type header and version.
+	if err := d.skip(int(message[0]) + 2); err != nil {
+		return nil, err
+	}
+	id, err := readUuid(d)
+	if err != nil {
+		return nil, err
+	}
+	op, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	processor, err := readString(d)
+	if err != nil {
+		return nil, err
+	}
+	args, err := readMapUnqualified(d)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.RequestMessage{
+		RequestID: id.(uuid.UUID),
+		Op:        op.(string),
+		Processor: processor.(string),
+		Args:      args.(map[string]interface{}),
+	}, nil
+}
+
+// writeGraphBinaryResponse serializes a response message into GraphBinary, as Gremlin Server does.
+func writeGraphBinaryResponse(response *wire.ResponseMessage) ([]byte, error) {
+	ser := newStandaloneTypeSerializer()
+	buffer := bytes.Buffer{}
+	buffer.WriteByte(versionByte)
+	ser.writeValueFlagNone(&buffer)
+	if _, err := ser.writeValue(response.RequestID, &buffer, false); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(&buffer, binary.BigEndian, uint32(response.StatusCode)); err != nil {
+		return nil, err
+	}
+	if response.StatusMessage == "" {
+		ser.writeValueFlagNull(&buffer)
+	} else if _, err := ser.writeValue(response.StatusMessage, &buffer, true); err != nil {
+		return nil, err
+	}
+	for _, m := range []map[string]interface{}{response.StatusAttributes, response.Meta} {
+		if err := binary.Write(&buffer, binary.BigEndian, uint32(len(m))); err != nil {
+			return nil, err
+		}
+		for k, v := range m {
+			if _, err := ser.write(k, &buffer); err != nil {
+				return nil, err
+			}
+			if _, err := ser.write(v, &buffer); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := ser.write(response.Data, &buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// readGraphBinaryResponse deserializes a response message written in GraphBinary, as the driver does. The message is
+// decoded while it is read from reader.
+func readGraphBinaryResponse(reader io.Reader) (*wire.ResponseMessage, error) {
+	response, err := graphBinarySerializer{newStandaloneTypeSerializer()}.deserializeMessage(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.ResponseMessage{
+		RequestID:        response.responseID,
+		StatusCode:       response.responseStatus.code,
+		StatusMessage:    response.responseStatus.message,
+		StatusAttributes: response.responseStatus.attributes,
+		Meta:             response.responseResult.meta,
+		Data:             response.responseResult.data,
+	}, nil
+}
+
+// marshalGraphBinary serializes a value into fully qualified GraphBinary.
+func marshalGraphBinary(value interface{}) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if _, err := newStandaloneTypeSerializer().write(value, &buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// unmarshalGraphBinary deserializes a fully qualified GraphBinary value, which may be one that only clients write.
+func unmarshalGraphBinary(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, newError(E0405ReadValueInvalidNullInputError)
+	}
+	d := newGraphBinaryDecoder(bytes.NewReader(data))
+	defer d.release()
+	d.request = true
+	return readFullyQualifiedNullable(d, true)
+}
@@ -20,9 +20,15 @@ under the License.
 package gremlingo
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/apache/tinkerpop/gremlin-go/v3/driver/internal/wire"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
@@ -51,7 +57,7 @@ func TestSerializer(t *testing.T) {
 	t.Run("test serialized response message", func(t *testing.T) {
 		responseByteArray := []byte{129, 0, 251, 37, 42, 74, 117, 221, 71, 191, 183, 78, 86, 53, 0, 12, 132, 100, 0, 0, 0, 200, 0, 0, 0, 0, 0, 0, 0, 0, 1, 3, 0, 0, 0, 0, 4, 104, 111, 115, 116, 3, 0, 0, 0, 0, 16, 47, 49, 50, 55, 46, 48, 46, 48, 46, 49, 58, 54, 50, 48, 51, 53, 0, 0, 0, 0, 9, 0, 0, 0, 0, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 		serializer := newGraphBinarySerializer(newLogHandler(&defaultLogger{}, Error, language.English))
-		response, _ := serializer.deserializeMessage(responseByteArray)
+		response, _ := serializer.deserializeMessage(bytes.NewReader(responseByteArray))
 		assert.Equal(t, "fb252a4a-75dd-47bf-b74e-5635000c8464", response.responseID.String())
 		assert.Equal(t, uint16(200), response.responseStatus.code)
 		assert.Equal(t, "", response.responseStatus.message)
@@ -59,6 +65,96 @@ func TestSerializer(t *testing.T) {
 		assert.Equal(t, map[string]interface{}{}, response.responseResult.meta)
 		assert.Equal(t, []interface{}{int64(0)}, response.responseResult.data)
 	})
+
+	t.Run("test readGraphBinaryRequest", func(t *testing.T) {
+		g := NewGraphTraversalSource(nil, nil)
+		traversal := g.WithStrategies(ReadOnlyStrategy()).V().Has("age", P.Gt(int32(30))).
+			Has("name", TextP.StartingWith("ma")).Order().By("age", Order.Desc).Map(&Lambda{Script: "it.get()"})
+		testRequest := makeBytecodeRequest(traversal.Bytecode, "g", "")
+		serializer := newGraphBinarySerializer(newLogHandler(&defaultLogger{}, Error, language.English))
+		serialized, err := serializer.serializeMessage(&testRequest)
+		assert.Nil(t, err)
+
+		request, err := readGraphBinaryRequest(serialized)
+		assert.Nil(t, err)
+		assert.Equal(t, testRequest.requestID, request.RequestID)
+		assert.Equal(t, bytecodeOp, request.Op)
+		assert.Equal(t, bytecodeProcessor, request.Processor)
+		assert.Equal(t, map[interface{}]interface{}{"g": "g"}, request.Args["aliases"])
+
+		bytecode, ok := request.Args["gremlin"].(*Bytecode)
+		assert.True(t, ok)
+		assert.Equal(t, len(traversal.Bytecode.sourceInstructions), len(bytecode.sourceInstructions))
+		assert.Equal(t, "withStrategies", bytecode.sourceInstructions[len(bytecode.sourceInstructions)-1].operator)
+		assert.Equal(t, []interface{}{"age", &p{operator: "gt", values: []interface{}{int32(30)}}},
+			bytecode.stepInstructions[1].arguments)
+		assert.Equal(t, &textP{operator: "startingWith", values: []interface{}{"ma"}},
+			bytecode.stepInstructions[2].arguments[1])
+		assert.Equal(t, []interface{}{"age", "desc"}, bytecode.stepInstructions[4].arguments)
+		assert.Equal(t, &Lambda{Script: "it.get()", Language: "gremlin-groovy"},
+			bytecode.stepInstructions[5].arguments[0])
+
+		serializedBytecode, err := marshalGraphBinary(traversal.Bytecode)
+		assert.Nil(t, err)
+		roundTripped, err := unmarshalGraphBinary(serializedBytecode)
+		assert.Nil(t, err)
+		assert.Equal(t, bytecode, roundTripped)
+	})
+
+	t.Run("test responses do not read request types", func(t *testing.T) {
+		g := NewGraphTraversalSource(nil, nil)
+		for _, value := range []interface{}{g.V().Bytecode, P.Gt(int32(1)), TextP.StartingWith("ma"),
+			&Lambda{Script: "it.get()"}, ReadOnlyStrategy()} {
+			serialized, err := writeGraphBinaryResponse(&wire.ResponseMessage{RequestID: uuid.New(), StatusCode: 200,
+				Data: value})
+			assert.Nil(t, err)
+			_, err = readGraphBinaryResponse(bytes.NewReader(serialized))
+			assert.True(t, isSameErrorCode(newError(E0408GetSerializerToReadUnknownTypeError), err))
+		}
+	})
+
+	t.Run("test writeGraphBinaryResponse", func(t *testing.T) {
+		id := uuid.New()
+		serialized, err := writeGraphBinaryResponse(&wire.ResponseMessage{
+			RequestID:        id,
+			StatusCode:       597,
+			StatusMessage:    "partial",
+			StatusAttributes: map[string]interface{}{"host": "localhost"},
+			Data:             []interface{}{int64(1), "two"},
+		})
+		assert.Nil(t, err)
+
+		serializer := newGraphBinarySerializer(newLogHandler(&defaultLogger{}, Error, language.English))
+		response, err := serializer.deserializeMessage(bytes.NewReader(serialized))
+		assert.Nil(t, err)
+		assert.Equal(t, id, response.responseID)
+		assert.Equal(t, uint16(597), response.responseStatus.code)
+		assert.Equal(t, "partial", response.responseStatus.message)
+		assert.Equal(t, map[string]interface{}{"host": "localhost"}, response.responseStatus.attributes)
+		assert.Equal(t, map[string]interface{}{}, response.responseResult.meta)
+		assert.Equal(t, []interface{}{int64(1), "two"}, response.responseResult.data)
+	})
+
+	t.Run("test readGraphBinaryResponse", func(t *testing.T) {
+		source := &wire.ResponseMessage{
+			RequestID:        uuid.New(),
+			StatusCode:       206,
+			StatusMessage:    "partial",
+			StatusAttributes: map[string]interface{}{"host": "localhost"},
+			Meta:             map[string]interface{}{"bulked": true},
+			Data:             []interface{}{map[interface{}]interface{}{"name": []interface{}{"marko"}}},
+		}
+		serialized, err := writeGraphBinaryResponse(source)
+		assert.Nil(t, err)
+
+		response, err := readGraphBinaryResponse(bytes.NewReader(serialized))
+		assert.Nil(t, err)
+		assert.Equal(t, source, response)
+
+		response, err = readGraphBinaryResponse(bytes.NewReader(nil))
+		assert.Nil(t, response)
+		assert.True(t, isSameErrorCode(newError(E0405ReadValueInvalidNullInputError), err))
+	})
 }
 
 func TestSerializerFailures(t *testing.T) {
@@ -75,6 +171,49 @@ func TestSerializerFailures(t *testing.T) {
 		resp, err := serializer.serializeMessage(&testRequest)
 		assert.Nil(t, resp)
 		assert.NotNil(t, err)
-		assert.True(t, isSameErrorCode(newError(err0704ConvertArgsNoSerializerError), err))
+		assert.True(t, isSameErrorCode(newError(E0704ConvertArgsNoSerializerError), err))
+	})
+
+	t.Run("test deserializeMessage truncated failure", func(t *testing.T) {
+		id := uuid.New()
+		serialized, err := writeGraphBinaryResponse(&wire.ResponseMessage{RequestID: id, StatusCode: 200,
+			Data: []interface{}{"truncated"}})
+		assert.Nil(t, err)
+
+		serializer := newGraphBinarySerializer(newLogHandler(&defaultLogger{}, Error, language.English))
+		response, err := serializer.deserializeMessage(bytes.NewReader(serialized[:len(serialized)-1]))
+		var decodingError *DecodingError
+		assert.True(t, errors.As(err, &decodingError))
+		assert.Equal(t, byte(stringType), decodingError.TypeCode)
+		// The header was read, so the response can still be matched to its request.
+		assert.Equal(t, id, response.responseID)
+	})
+}
+
+// FuzzDeserializeMessage checks that deserializing malformed responses fails with an error instead of panicking.
+func FuzzDeserializeMessage(f *testing.F) {
+	for _, data := range []interface{}{
+		[]interface{}{int64(1), "two", 3.0, true, uuid.New()},
+		[]interface{}{map[interface{}]interface{}{"name": []interface{}{"marko"}, T.Id: int32(1)}},
+		[]interface{}{newTestGraph(), newTestTree(), NewSimpleSet("a", "b")},
+		[]interface{}{&Path{Labels: []Set{NewSimpleSet("a")}, Objects: []interface{}{"marko"}}},
+		[]interface{}{time.Unix(1, 2), time.Duration(3), LocalDateTime{}, OffsetTime{}, Period{1, 2, 3}},
+		[]interface{}{big.NewInt(-12345), &BigDecimal{Scale: 2, UnscaledValue: *big.NewInt(1)}, net.IP{127, 0, 0, 1}},
+	} {
+		serialized, err := writeGraphBinaryResponse(&wire.ResponseMessage{RequestID: uuid.New(), StatusCode: 206,
+			StatusMessage: "partial", StatusAttributes: map[string]interface{}{"host": "localhost"}, Data: data})
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(serialized)
+	}
+
+	serializer := newGraphBinarySerializer(newLogHandler(&defaultLogger{}, Off, language.English))
+	f.Fuzz(func(t *testing.T, message []byte) {
+		_, err := serializer.deserializeMessage(bytes.NewReader(message))
+		if err != nil {
+			var gremlinError *GremlinError
+			assert.True(t, errors.As(err, &gremlinError), "unexpected error: %v", err)
+		}
 	})
 }
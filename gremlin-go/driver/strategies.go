@@ -19,6 +19,8 @@ under the License.
 
 package gremlingo
 
+import "strings"
+
 const (
 	baseNamespace               = "org.apache.tinkerpop.gremlin.process.traversal.strategy."
 	decorationNamespace         = baseNamespace + "decoration."
@@ -37,6 +39,11 @@ type traversalStrategy struct {
 	apply         func(g GraphTraversal)
 }
 
+// simpleName returns the name of the class of the strategy without its package.
+func (strategy *traversalStrategy) simpleName() string {
+	return strategy.name[strings.LastIndex(strategy.name, ".")+1:]
+}
+
 // Decoration strategies
 
 // ConnectiveStrategy rewrites the binary conjunction form of a.And().b into a AndStep of
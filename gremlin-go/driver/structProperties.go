@@ -0,0 +1,187 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"reflect"
+)
+
+// StructProperties is a struct which is written as a property map, as returned by Props.
+type StructProperties struct {
+	value interface{}
+}
+
+// Props returns a property map of the fields of a struct, or of a pointer to a struct, which can be passed where
+// steps such as MergeV, MergeE and Property expect a map. Fields are keyed by their `gremlin` tag, or else by their
+// name, and fields tagged `gremlin:"-"` are skipped. The tags `gremlin:"id,T.id"` and `gremlin:"label,T.label"` key a
+// field by T.id and T.label, and `omitempty` skips empty values. Where Props is passed to Property, `single`, `list`
+// or `set` set the property with that Cardinality, where each element of a list or set property is added as a value
+// of it, and otherwise properties are set with the default cardinality of the graph:
+//
+//	type Person struct {
+//		ID    int64    `gremlin:"id,T.id,omitempty"`
+//		Label string   `gremlin:"label,T.label,omitempty"`
+//		Name  string   `gremlin:"name"`
+//		Nicks []string `gremlin:"nicks,set,omitempty"`
+//	}
+//
+//	g.MergeV(gremlingo.Props(person))
+//	g.V(id).Property(gremlingo.Props(person))
+func Props(value interface{}) *StructProperties {
+	return &StructProperties{value: value}
+}
+
+// structProperty is a field of a struct which is written as a property.
+type structProperty struct {
+	key         interface{}
+	value       interface{}
+	cardinality cardinality
+}
+
+// Map returns the property map of the struct, or an error if it is not a struct. The map holds no cardinalities, which
+// only take effect where Props is passed to Property.
+func (props *StructProperties) Map() (map[interface{}]interface{}, error) {
+	properties, err := props.properties()
+	if err != nil {
+		return nil, err
+	}
+	propertyMap := make(map[interface{}]interface{}, len(properties))
+	for _, property := range properties {
+		propertyMap[property.key] = property.value
+	}
+	return propertyMap, nil
+}
+
+// properties returns the properties of the struct in the order of its fields, or an error if it is not a struct.
+func (props *StructProperties) properties() ([]structProperty, error) {
+	value := reflect.ValueOf(props.value)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, newError(E1002ConvertArgumentNotStructError, props.value)
+	}
+	fields := structFields(value.Type())
+	properties := make([]structProperty, 0, len(fields))
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.index)
+		if field.tag.omitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+		var key interface{} = field.tag.name
+		if field.tag.token != "" {
+			key = field.tag.token
+		}
+		properties = append(properties, structProperty{key, propertyValue(fieldValue), field.tag.cardinality})
+	}
+	return properties, nil
+}
+
+// propertySteps returns the arguments of the property steps which set the properties of a struct, if args are Props,
+// optionally preceded by a Cardinality for all of its fields. Properties with a cardinality, given by their tag or else
+// by args, are set with a step each, such as property(set,'nicks','okram'), where list and set properties are set
+// with a step per element. The other properties, and T.id and T.label, are set with a single step of a map.
+func propertySteps(args []interface{}) ([][]interface{}, bool) {
+	var defaultCardinality cardinality
+	switch len(args) {
+	case 1:
+	case 2:
+		card, ok := args[0].(cardinality)
+		if !ok {
+			return nil, false
+		}
+		defaultCardinality = card
+	default:
+		return nil, false
+	}
+	props, ok := args[len(args)-1].(*StructProperties)
+	if !ok {
+		return nil, false
+	}
+	properties, err := props.properties()
+	if err != nil {
+		return nil, false
+	}
+	propertyMap := make(map[interface{}]interface{})
+	var steps [][]interface{}
+	for _, property := range properties {
+		card := property.cardinality
+		if card == "" {
+			card = defaultCardinality
+		}
+		if _, token := property.key.(t); token || card == "" {
+			propertyMap[property.key] = property.value
+			continue
+		}
+		if elements, ok := propertyElements(property.value); ok && card != Cardinality.Single {
+			for _, element := range elements {
+				steps = append(steps, []interface{}{card, property.key, element})
+			}
+			continue
+		}
+		steps = append(steps, []interface{}{card, property.key, property.value})
+	}
+	if len(propertyMap) > 0 {
+		steps = append([][]interface{}{{propertyMap}}, steps...)
+	}
+	return steps, true
+}
+
+// propertyElements returns the elements of a slice or an array, other than a []byte, which is a single value.
+func propertyElements(value interface{}) ([]interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	elements := make([]interface{}, v.Len())
+	for i := range elements {
+		elements[i] = v.Index(i).Interface()
+	}
+	return elements, true
+}
+
+// propertyValue returns the value of a field, where pointers to numbers, strings and booleans are written as the
+// values they point to.
+func propertyValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		switch value.Elem().Kind() {
+		case reflect.Bool, reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return value.Elem().Interface()
+		}
+	}
+	return value.Interface()
+}
+
+// isEmptyValue reports whether a field is empty in the sense of omitempty, as with encoding/json. Structs, such as
+// time.Time, are empty if they are zero.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
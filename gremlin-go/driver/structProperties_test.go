@@ -0,0 +1,193 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type propsTestPerson struct {
+	ID       int64     `gremlin:"id,T.id,omitempty"`
+	Label    string    `gremlin:"label,T.label"`
+	Name     string    `gremlin:"name"`
+	Age      *int32    `gremlin:"age,omitempty"`
+	Nicks    []string  `gremlin:"nicks,set,omitempty"`
+	Visits   []int64   `gremlin:"visits,list"`
+	Email    string    `gremlin:"email,single"`
+	Created  time.Time `gremlin:"created,omitempty"`
+	Country  string
+	Password string `gremlin:"-"`
+	internal string
+}
+
+func TestStructProperties(t *testing.T) {
+	t.Run("Test Props Map", func(t *testing.T) {
+		age := int32(29)
+		created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		props, err := Props(&propsTestPerson{
+			ID:       1,
+			Label:    "person",
+			Name:     "marko",
+			Age:      &age,
+			Nicks:    []string{"okram"},
+			Visits:   []int64{1, 2},
+			Email:    "marko@example.com",
+			Created:  created,
+			Country:  "US",
+			Password: "secret",
+			internal: "internal",
+		}).Map()
+		assert.Nil(t, err)
+		assert.Equal(t, map[interface{}]interface{}{
+			T.Id:      int64(1),
+			T.Label:   "person",
+			"name":    "marko",
+			"age":     int32(29),
+			"nicks":   []string{"okram"},
+			"visits":  []int64{1, 2},
+			"email":   "marko@example.com",
+			"created": created,
+			"Country": "US",
+		}, props)
+	})
+
+	t.Run("Test Props omitempty", func(t *testing.T) {
+		props, err := Props(propsTestPerson{Label: "person"}).Map()
+		assert.Nil(t, err)
+		assert.Equal(t, map[interface{}]interface{}{
+			T.Label:   "person",
+			"name":    "",
+			"visits":  []int64(nil),
+			"email":   "",
+			"Country": "",
+		}, props)
+	})
+
+	t.Run("Test Props embedded struct", func(t *testing.T) {
+		type base struct {
+			Label string `gremlin:"label,T.label"`
+		}
+		props, err := Props(struct {
+			base
+			Name string `gremlin:"name"`
+		}{base{"person"}, "marko"}).Map()
+		assert.Nil(t, err)
+		assert.Equal(t, map[interface{}]interface{}{T.Label: "person", "name": "marko"}, props)
+	})
+
+	t.Run("Test Props not a struct failure", func(t *testing.T) {
+		_, err := Props(map[string]interface{}{"name": "marko"}).Map()
+		assert.True(t, isSameErrorCode(newError(E1002ConvertArgumentNotStructError), err))
+		_, err = Props((*propsTestPerson)(nil)).Map()
+		assert.True(t, isSameErrorCode(newError(E1002ConvertArgumentNotStructError), err))
+	})
+
+	t.Run("Test Props as step argument", func(t *testing.T) {
+		bc := NewBytecode(nil)
+		err := bc.AddStep("mergeV", Props(struct {
+			Name  string   `gremlin:"name"`
+			Nicks []string `gremlin:"nicks"`
+		}{"marko", []string{"mark", "okram"}}))
+		assert.Nil(t, err)
+		assert.Equal(t, []instruction{{
+			operator: "mergeV",
+			arguments: []interface{}{map[interface{}]interface{}{
+				"name":  "marko",
+				"nicks": []interface{}{"mark", "okram"},
+			}},
+		}}, bc.stepInstructions)
+
+		err = bc.AddStep("property", Props(1))
+		assert.True(t, isSameErrorCode(newError(E1002ConvertArgumentNotStructError), err))
+	})
+
+	t.Run("Test Props as property step argument", func(t *testing.T) {
+		g := &GraphTraversalSource{graph: &Graph{}, bytecode: NewBytecode(nil)}
+		age := int32(29)
+		traversal := g.AddV("person").Property(Props(&propsTestPerson{
+			ID:     1,
+			Label:  "person",
+			Name:   "marko",
+			Age:    &age,
+			Nicks:  []string{"mark", "okram"},
+			Visits: []int64{1, 1},
+			Email:  "marko@example.com",
+		}))
+		assert.Equal(t, []instruction{
+			{operator: "addV", arguments: []interface{}{"person"}},
+			{operator: "property", arguments: []interface{}{map[interface{}]interface{}{
+				T.Id: int64(1), T.Label: "person", "name": "marko", "age": int32(29), "Country": ""}}},
+			{operator: "property", arguments: []interface{}{Cardinality.Set, "nicks", "mark"}},
+			{operator: "property", arguments: []interface{}{Cardinality.Set, "nicks", "okram"}},
+			{operator: "property", arguments: []interface{}{Cardinality.List, "visits", int64(1)}},
+			{operator: "property", arguments: []interface{}{Cardinality.List, "visits", int64(1)}},
+			{operator: "property", arguments: []interface{}{Cardinality.Single, "email", "marko@example.com"}},
+		}, traversal.Bytecode.stepInstructions)
+	})
+
+	t.Run("Test Props as property step argument with cardinality", func(t *testing.T) {
+		g := &GraphTraversalSource{graph: &Graph{}, bytecode: NewBytecode(nil)}
+		traversal := g.V(int64(1)).Property(Cardinality.List, Props(struct {
+			Label string   `gremlin:"label,T.label"`
+			Name  string   `gremlin:"name"`
+			Nicks []string `gremlin:"nicks"`
+			Email string   `gremlin:"email,single"`
+		}{"person", "marko", []string{"mark", "okram"}, "marko@example.com"}))
+		assert.Equal(t, []instruction{
+			{operator: "V", arguments: []interface{}{int64(1)}},
+			{operator: "property", arguments: []interface{}{map[interface{}]interface{}{T.Label: "person"}}},
+			{operator: "property", arguments: []interface{}{Cardinality.List, "name", "marko"}},
+			{operator: "property", arguments: []interface{}{Cardinality.List, "nicks", "mark"}},
+			{operator: "property", arguments: []interface{}{Cardinality.List, "nicks", "okram"}},
+			{operator: "property", arguments: []interface{}{Cardinality.Single, "email", "marko@example.com"}},
+		}, traversal.Bytecode.stepInstructions)
+
+		script, err := NewTranslator("g", GremlinGroovy).Translate(g.V(int64(1)).Property(Props(struct {
+			Nicks []string `gremlin:"nicks,set"`
+		}{[]string{"okram"}})).Bytecode)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.V(1L).property(VertexProperty.Cardinality.set,'nicks','okram')", script)
+	})
+
+	t.Run("Test Props not a struct as property step argument", func(t *testing.T) {
+		g := &GraphTraversalSource{graph: &Graph{}, bytecode: NewBytecode(nil)}
+		traversal := g.V().Property(Props(1)).Property(Cardinality.Set, Props("marko"))
+		assert.Equal(t, []instruction{{operator: "V", arguments: []interface{}{}}}, traversal.Bytecode.stepInstructions)
+	})
+
+	t.Run("Test Props GraphBinary round trip", func(t *testing.T) {
+		g := &GraphTraversalSource{graph: &Graph{}, bytecode: NewBytecode(nil)}
+		traversal := g.MergeV(Props(struct {
+			Label string   `gremlin:"label,T.label"`
+			Nicks []string `gremlin:"nicks"`
+		}{"person", []string{"okram"}}))
+		data, err := marshalGraphBinary(traversal.Bytecode)
+		assert.Nil(t, err)
+		value, err := unmarshalGraphBinary(data)
+		assert.Nil(t, err)
+		bytecode := value.(*Bytecode)
+		properties := bytecode.stepInstructions[0].arguments[0].(map[interface{}]interface{})
+		assert.Equal(t, "person", properties["label"])
+		assert.Equal(t, []interface{}{"okram"}, properties["nicks"])
+	})
+}
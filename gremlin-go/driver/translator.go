@@ -0,0 +1,563 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TranslatorSyntax is the syntax of the Gremlin scripts which a Translator writes.
+type TranslatorSyntax int
+
+const (
+	// GremlinGroovy is the syntax of the gremlin-groovy script engine, as used by the Gremlin Console.
+	GremlinGroovy TranslatorSyntax = iota
+	// GremlinLang is the canonical syntax of the gremlin-language grammar.
+	GremlinLang
+)
+
+// Translator translates Bytecode into a Gremlin script, for example to log a traversal, to run it in the Gremlin
+// Console or to submit it to a server which only accepts scripts.
+type Translator struct {
+	traversalSource string
+	syntax          TranslatorSyntax
+}
+
+// NewTranslator creates a Translator which writes scripts of the given syntax that start with the variable name of
+// the traversal source, usually "g".
+func NewTranslator(traversalSource string, syntax TranslatorSyntax) *Translator {
+	return &Translator{traversalSource: traversalSource, syntax: syntax}
+}
+
+// Translate returns the script of the Bytecode. Bindings are written as the variables they name, and an error is
+// returned for arguments which have no representation in the syntax of the Translator.
+func (translator *Translator) Translate(bytecode *Bytecode) (string, error) {
+	script := &scriptBuilder{syntax: translator.syntax}
+	if err := script.writeTraversal(translator.traversalSource, bytecode); err != nil {
+		return "", err
+	}
+	return script.String(), nil
+}
+
+// translateParameterized returns the script of the Bytecode in which literal values are replaced by variables, along
+// with the bindings of those variables and of the Bindings of the Bytecode. Traversals which only differ in their
+// literals thus have the same script, which the server compiles only once. Once there are maxParameters bindings,
+// further literals are written into the script, as the server rejects requests with more parameters than that.
+func (translator *Translator) translateParameterized(bytecode *Bytecode, maxParameters int) (string,
+	map[string]interface{}, error) {
+	bindings := make(map[string]interface{}, len(bytecode.bindings))
+	for key, value := range bytecode.bindings {
+		bindings[key] = value
+	}
+	script := &scriptBuilder{syntax: translator.syntax, bindings: bindings, maxParameters: maxParameters}
+	if err := script.writeTraversal(translator.traversalSource, bytecode); err != nil {
+		return "", nil, err
+	}
+	return script.String(), bindings, nil
+}
+
+// parameterPrefix is the prefix of the variables which literals are hoisted into by translateParameterized.
+const parameterPrefix = "_args_"
+
+// defaultScriptOnlyMaxParameters is the maximum number of bindings of a translated traversal by default, which is the
+// default maxParameters of Gremlin Server.
+const defaultScriptOnlyMaxParameters = 16
+
+// scriptBuilder writes the script of a traversal. If bindings is set, literals are written as variables bound to them
+// as long as there are fewer than maxParameters bindings.
+type scriptBuilder struct {
+	strings.Builder
+	syntax        TranslatorSyntax
+	bindings      map[string]interface{}
+	maxParameters int
+	parameters    int
+}
+
+func (script *scriptBuilder) writeTraversal(start string, bytecode *Bytecode) error {
+	script.WriteString(start)
+	instructions := append(append([]instruction{}, bytecode.sourceInstructions...), bytecode.stepInstructions...)
+	if start == "__" && len(instructions) == 0 {
+		instructions = []instruction{{operator: "identity"}}
+	}
+	for _, instruction := range instructions {
+		if instruction.operator == "tx" && len(instruction.arguments) == 1 {
+			// Transactions are submitted as the tx source instruction with the name of the method to call on it.
+			if method, ok := instruction.arguments[0].(string); ok {
+				script.WriteString(".tx()." + method + "()")
+				continue
+			}
+		}
+		arguments := instruction.arguments
+		if script.syntax == GremlinLang {
+			switch instruction.operator {
+			case "withStrategies":
+				var err error
+				if arguments, err = script.writeOptions(arguments); err != nil {
+					return err
+				}
+				if len(arguments) == 0 {
+					continue
+				}
+			case "withoutStrategies":
+				// The grammar has no withoutStrategies().
+				return newError(E1301TranslatorUnsupportedValueError, arguments[0])
+			}
+		}
+		script.WriteString(".")
+		script.WriteString(instruction.operator)
+		script.WriteString("(")
+		for i, argument := range arguments {
+			if i > 0 {
+				script.WriteString(",")
+			}
+			var err error
+			if strategy, ok := argument.(*traversalStrategy); ok && instruction.operator == "withoutStrategies" {
+				script.WriteString(strategy.simpleName())
+			} else {
+				err = script.writeValue(argument)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		script.WriteString(")")
+	}
+	return nil
+}
+
+// writeOptions writes the configuration of the OptionsStrategy among the strategies as with() steps, which is how
+// the gremlin-language grammar sets options, and returns the other strategies.
+func (script *scriptBuilder) writeOptions(strategies []interface{}) ([]interface{}, error) {
+	others := make([]interface{}, 0, len(strategies))
+	for _, argument := range strategies {
+		strategy, ok := argument.(*traversalStrategy)
+		if !ok || strategy.name != decorationNamespace+"OptionsStrategy" {
+			others = append(others, argument)
+			continue
+		}
+		keys := make([]string, 0, len(strategy.configuration))
+		for key := range strategy.configuration {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			script.WriteString(".with(")
+			script.writeString(key)
+			script.WriteString(",")
+			if err := script.writeValue(strategy.configuration[key]); err != nil {
+				return nil, err
+			}
+			script.WriteString(")")
+		}
+	}
+	return others, nil
+}
+
+func (script *scriptBuilder) writeValue(value interface{}) error {
+	if script.bindings != nil && len(script.bindings) < script.maxParameters && isLiteral(value) {
+		name := parameterPrefix + strconv.Itoa(script.parameters)
+		script.parameters++
+		script.bindings[name] = value
+		script.WriteString(name)
+		return nil
+	}
+	switch v := value.(type) {
+	case nil:
+		script.WriteString("null")
+	case string:
+		script.writeString(v)
+	case bool:
+		script.WriteString(strconv.FormatBool(v))
+	case int8, int16, int32, int64, int, uint8, uint16, uint32, uint, uint64, float32, float64, *big.Int, BigDecimal,
+		*BigDecimal:
+		script.writeNumber(v)
+	case *Bytecode:
+		return script.writeTraversal("__", v)
+	case Bytecode:
+		return script.writeTraversal("__", &v)
+	case *GraphTraversal:
+		return script.writeTraversal("__", v.Bytecode)
+	case *Traversal:
+		return script.writeTraversal("__", v.Bytecode)
+	case *Binding:
+		script.WriteString(v.Key)
+	case *Lambda:
+		return script.writeLambda(v)
+	case *p:
+		return script.writePredicate("P", v.operator, v.values)
+	case p:
+		return script.writePredicate("P", v.operator, v.values)
+	case *textP:
+		return script.writePredicate("TextP", v.operator, v.values)
+	case textP:
+		return script.writePredicate("TextP", v.operator, v.values)
+	case *traversalStrategy:
+		return script.writeStrategy(v)
+	case *Vertex:
+		script.WriteString("new ReferenceVertex(")
+		if err := script.writeValue(v.Id); err != nil {
+			return err
+		}
+		script.WriteString(",")
+		script.writeString(v.Label)
+		script.WriteString(")")
+	case time.Time:
+		if script.syntax == GremlinLang {
+			script.WriteString("datetime('" + v.UTC().Format("2006-01-02T15:04:05.000Z") + "')")
+		} else {
+			script.WriteString("new Date(" + strconv.FormatInt(v.UnixMilli(), 10) + "L)")
+		}
+	case uuid.UUID:
+		if script.syntax == GremlinLang {
+			// The grammar has no UUID literal.
+			return newError(E1301TranslatorUnsupportedValueError, v)
+		}
+		script.WriteString("UUID.fromString('" + v.String() + "')")
+	case t:
+		script.WriteString("T." + string(v))
+	case direction:
+		script.WriteString("Direction." + string(v))
+	case order:
+		script.WriteString("Order." + string(v))
+	case pop:
+		script.WriteString("Pop." + string(v))
+	case scope:
+		script.WriteString("Scope." + string(v))
+	case column:
+		script.WriteString("Column." + string(v))
+	case merge:
+		script.WriteString("Merge." + string(v))
+	case operator:
+		script.WriteString("Operator." + string(v))
+	case pick:
+		script.WriteString("Pick." + string(v))
+	case barrier:
+		script.WriteString(script.qualified("SackFunctions.Barrier.", "Barrier.") + string(v))
+	case cardinality:
+		script.WriteString(script.qualified("VertexProperty.Cardinality.", "Cardinality.") + string(v))
+	case Set:
+		return script.writeSet(v)
+	case map[interface{}]interface{}:
+		return script.writeMap(v)
+	case []interface{}:
+		return script.writeList(v)
+	default:
+		return script.writeReflectedValue(value)
+	}
+	return nil
+}
+
+// isLiteral returns whether a value is written as a literal, rather than as code such as a traversal or an enum.
+func isLiteral(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int8, int16, int32, int64, int, uint8, uint16, uint32, uint, uint64, float32, float64, *big.Int,
+		BigDecimal, *BigDecimal, time.Time, uuid.UUID, *Vertex:
+		return true
+	}
+	return false
+}
+
+// qualified returns the prefix of enum values, which are nested classes in Java.
+func (script *scriptBuilder) qualified(groovyPrefix, gremlinLangPrefix string) string {
+	if script.syntax == GremlinLang {
+		return gremlinLangPrefix
+	}
+	return groovyPrefix
+}
+
+// writeReflectedValue writes maps and slices of other types than those which Bytecode converts arguments to, such as
+// those of strategy configurations.
+func (script *scriptBuilder) writeReflectedValue(value interface{}) error {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		entries := make(map[interface{}]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries[iter.Key().Interface()] = iter.Value().Interface()
+		}
+		return script.writeMap(entries)
+	case reflect.Slice, reflect.Array:
+		items, _ := resultItems(value)
+		return script.writeList(items)
+	}
+	return newError(E1301TranslatorUnsupportedValueError, value)
+}
+
+func (script *scriptBuilder) writeString(s string) {
+	script.WriteString("'")
+	for _, r := range s {
+		switch r {
+		case '\\':
+			script.WriteString(`\\`)
+		case '\'':
+			script.WriteString(`\'`)
+		case '\n':
+			script.WriteString(`\n`)
+		case '\r':
+			script.WriteString(`\r`)
+		case '\t':
+			script.WriteString(`\t`)
+		default:
+			script.WriteRune(r)
+		}
+	}
+	script.WriteString("'")
+}
+
+// writeNumber writes a number as a literal of the Java type it is serialized as.
+func (script *scriptBuilder) writeNumber(number interface{}) {
+	groovy := script.syntax == GremlinGroovy
+	switch n := number.(type) {
+	case int32:
+		script.WriteString(strconv.FormatInt(int64(n), 10))
+	case uint16:
+		script.WriteString(strconv.FormatInt(int64(n), 10))
+	case int64:
+		script.WriteString(strconv.FormatInt(n, 10) + "L")
+	case int:
+		script.WriteString(strconv.FormatInt(int64(n), 10) + "L")
+	case uint32:
+		script.WriteString(strconv.FormatInt(int64(n), 10) + "L")
+	case int8, int16:
+		value := reflect.ValueOf(n).Int()
+		if groovy {
+			script.WriteString("(short) " + strconv.FormatInt(value, 10))
+		} else {
+			script.WriteString(strconv.FormatInt(value, 10) + "s")
+		}
+	case uint8:
+		if groovy {
+			script.WriteString("(byte) " + strconv.FormatInt(int64(int8(n)), 10))
+		} else {
+			script.WriteString(strconv.FormatInt(int64(int8(n)), 10) + "b")
+		}
+	case uint:
+		script.writeBigInteger(new(big.Int).SetUint64(uint64(n)))
+	case uint64:
+		script.writeBigInteger(new(big.Int).SetUint64(n))
+	case *big.Int:
+		script.writeBigInteger(n)
+	case float32:
+		script.writeFloat(float64(n), 32)
+	case float64:
+		script.writeFloat(n, 64)
+	case BigDecimal:
+		script.writeBigDecimal(&n)
+	case *BigDecimal:
+		script.writeBigDecimal(n)
+	}
+}
+
+func (script *scriptBuilder) writeBigInteger(n *big.Int) {
+	script.WriteString(n.String())
+	script.WriteString(script.qualified("g", "n"))
+}
+
+func (script *scriptBuilder) writeFloat(f float64, bitSize int) {
+	suffix, class := "d", "Double."
+	if bitSize == 32 {
+		suffix, class = "f", "Float."
+	}
+	switch {
+	case math.IsNaN(f):
+		script.WriteString(script.qualified(class+"NaN", "NaN"))
+	case math.IsInf(f, 1):
+		script.WriteString(script.qualified(class+"POSITIVE_INFINITY", "Infinity"))
+	case math.IsInf(f, -1):
+		script.WriteString(script.qualified(class+"NEGATIVE_INFINITY", "-Infinity"))
+	default:
+		s := strconv.FormatFloat(f, 'g', -1, bitSize)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		script.WriteString(s + suffix)
+	}
+}
+
+func (script *scriptBuilder) writeBigDecimal(n *BigDecimal) {
+	digits := new(big.Int).Abs(&n.UnscaledValue).String()
+	if n.Scale < 0 {
+		digits += strings.Repeat("0", int(-n.Scale))
+	} else if n.Scale > 0 {
+		if len(digits) <= int(n.Scale) {
+			digits = strings.Repeat("0", int(n.Scale)-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-int(n.Scale)] + "." + digits[len(digits)-int(n.Scale):]
+	}
+	if n.UnscaledValue.Sign() < 0 {
+		script.WriteString("-")
+	}
+	script.WriteString(digits)
+	script.WriteString(script.qualified("g", "m"))
+}
+
+func (script *scriptBuilder) writeLambda(lambda *Lambda) error {
+	switch lambda.Language {
+	case "", "gremlin-groovy", "groovy":
+	default:
+		return newError(E1302TranslatorUnsupportedLambdaError, lambda.Language)
+	}
+	if script.syntax == GremlinLang {
+		// The grammar has no lambdas.
+		return newError(E1302TranslatorUnsupportedLambdaError, "gremlin-groovy")
+	}
+	body := strings.TrimSpace(lambda.Script)
+	if !strings.HasPrefix(body, "{") {
+		body = "{" + body + "}"
+	}
+	script.WriteString(body)
+	return nil
+}
+
+func (script *scriptBuilder) writePredicate(class string, operator string, values []interface{}) error {
+	switch operator {
+	case "and", "or":
+		if len(values) == 2 {
+			if err := script.writeValue(values[0]); err != nil {
+				return err
+			}
+			script.WriteString("." + operator + "(")
+			if err := script.writeValue(values[1]); err != nil {
+				return err
+			}
+			script.WriteString(")")
+			return nil
+		}
+	}
+	script.WriteString(class + "." + operator + "(")
+	for i, value := range values {
+		if i > 0 {
+			script.WriteString(",")
+		}
+		if err := script.writeValue(value); err != nil {
+			return err
+		}
+	}
+	script.WriteString(")")
+	return nil
+}
+
+func (script *scriptBuilder) writeStrategy(strategy *traversalStrategy) error {
+	if script.syntax == GremlinLang && strategy.simpleName() == "ReadOnlyStrategy" {
+		// The grammar only has ReadOnlyStrategy without a constructor, as it takes no configuration.
+		script.WriteString(strategy.simpleName())
+		return nil
+	}
+	script.WriteString("new " + strategy.simpleName() + "(")
+	keys := make([]string, 0, len(strategy.configuration))
+	for key := range strategy.configuration {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		if i > 0 {
+			script.WriteString(",")
+		}
+		script.WriteString(key + ":")
+		if err := script.writeValue(strategy.configuration[key]); err != nil {
+			return err
+		}
+	}
+	script.WriteString(")")
+	return nil
+}
+
+func (script *scriptBuilder) writeList(items []interface{}) error {
+	script.WriteString("[")
+	if err := script.writeItems(items); err != nil {
+		return err
+	}
+	script.WriteString("]")
+	return nil
+}
+
+func (script *scriptBuilder) writeSet(set Set) error {
+	if script.syntax == GremlinLang {
+		// The grammar has no set literal.
+		return newError(E1301TranslatorUnsupportedValueError, set)
+	}
+	items := set.ToSlice()
+	if err := script.writeList(items); err != nil {
+		return err
+	}
+	script.WriteString(" as Set")
+	return nil
+}
+
+func (script *scriptBuilder) writeItems(items []interface{}) error {
+	for i, item := range items {
+		if i > 0 {
+			script.WriteString(",")
+		}
+		if err := script.writeValue(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMap writes the entries of a map ordered by their keys, so that the script of a traversal does not change. Keys
+// which are not strings are put in parentheses.
+func (script *scriptBuilder) writeMap(entries map[interface{}]interface{}) error {
+	if len(entries) == 0 {
+		script.WriteString("[:]")
+		return nil
+	}
+	type entry struct {
+		key   string
+		value interface{}
+	}
+	rendered := make([]entry, 0, len(entries))
+	for key, value := range entries {
+		keyScript := &scriptBuilder{syntax: script.syntax}
+		if err := keyScript.writeValue(key); err != nil {
+			return err
+		}
+		k := keyScript.String()
+		if _, ok := key.(string); !ok {
+			k = "(" + k + ")"
+		}
+		rendered = append(rendered, entry{k, value})
+	}
+	sort.Slice(rendered, func(i, j int) bool {
+		return rendered[i].key < rendered[j].key
+	})
+	script.WriteString("[")
+	for i, e := range rendered {
+		if i > 0 {
+			script.WriteString(",")
+		}
+		script.WriteString(e.key + ":")
+		if err := script.writeValue(e.value); err != nil {
+			return err
+		}
+	}
+	script.WriteString("]")
+	return nil
+}
@@ -0,0 +1,284 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslator(t *testing.T) {
+	g := &GraphTraversalSource{graph: &Graph{}, bytecode: NewBytecode(nil)}
+	groovy := NewTranslator("g", GremlinGroovy)
+	gremlinLang := NewTranslator("g", GremlinLang)
+
+	tests := []struct {
+		name        string
+		traversal   *GraphTraversal
+		groovy      string
+		gremlinLang string
+	}{
+		{
+			name:        "steps",
+			traversal:   g.V().HasLabel("person").Has("name", "marko").Out("knows").Values("name"),
+			groovy:      "g.V().hasLabel('person').has('name','marko').out('knows').values('name')",
+			gremlinLang: "g.V().hasLabel('person').has('name','marko').out('knows').values('name')",
+		},
+		{
+			name:        "escaped strings",
+			traversal:   g.Inject("it's", "a\\b\n"),
+			groovy:      `g.inject('it\'s','a\\b\n')`,
+			gremlinLang: `g.inject('it\'s','a\\b\n')`,
+		},
+		{
+			name: "numbers",
+			traversal: g.V(int32(1), int64(2), 3, int16(4), uint8(5), float32(1.5), 2.0, big.NewInt(7),
+				&BigDecimal{Scale: 2, UnscaledValue: *big.NewInt(-125)}, math.NaN(), math.Inf(1)),
+			groovy: "g.V(1,2L,3L,(short) 4,(byte) 5,1.5f,2.0d,7g,-1.25g,Double.NaN," +
+				"Double.POSITIVE_INFINITY)",
+			gremlinLang: "g.V(1,2L,3L,4s,5b,1.5f,2.0d,7n,-1.25m,NaN,Infinity)",
+		},
+		{
+			name:        "null and booleans",
+			traversal:   g.Inject(nil, true, false),
+			groovy:      "g.inject(null,true,false)",
+			gremlinLang: "g.inject(null,true,false)",
+		},
+		{
+			name:        "predicates",
+			traversal:   g.V().Has("age", P.Gt(int32(30)).And(P.Lt(int32(40)))).Has("name", P.Within("marko", "josh")),
+			groovy:      "g.V().has('age',P.gt(30).and(P.lt(40))).has('name',P.within('marko','josh'))",
+			gremlinLang: "g.V().has('age',P.gt(30).and(P.lt(40))).has('name',P.within('marko','josh'))",
+		},
+		{
+			name:        "text predicates",
+			traversal:   g.V().Has("name", TextP.StartingWith("m").Or(TextP.Containing("o"))).Has("age", P.Not(P.Eq(int32(1)))),
+			groovy:      "g.V().has('name',TextP.startingWith('m').or(TextP.containing('o'))).has('age',P.not(P.eq(1)))",
+			gremlinLang: "g.V().has('name',TextP.startingWith('m').or(TextP.containing('o'))).has('age',P.not(P.eq(1)))",
+		},
+		{
+			name: "enums",
+			traversal: g.V().Order().By("age", Order.Desc).Select(Pop.Last, "a").Group().By(T.Label).
+				By(Column.Values).Local(T__.BothE().Count()).To(Direction.Out, "x").Property(Cardinality.List, "nick", "m"),
+			groovy: "g.V().order().by('age',Order.desc).select(Pop.last,'a').group().by(T.label).by(Column.values)." +
+				"local(__.bothE().count()).to(Direction.OUT,'x').property(VertexProperty.Cardinality.list,'nick','m')",
+			gremlinLang: "g.V().order().by('age',Order.desc).select(Pop.last,'a').group().by(T.label).by(Column.values)." +
+				"local(__.bothE().count()).to(Direction.OUT,'x').property(Cardinality.list,'nick','m')",
+		},
+		{
+			name:        "nested anonymous traversals",
+			traversal:   g.V().Repeat(T__.Out().Where(T__.Has("age", P.Gt(int32(30))))).Until(T__.HasLabel("software")),
+			groovy:      "g.V().repeat(__.out().where(__.has('age',P.gt(30)))).until(__.hasLabel('software'))",
+			gremlinLang: "g.V().repeat(__.out().where(__.has('age',P.gt(30)))).until(__.hasLabel('software'))",
+		},
+		{
+			name: "maps lists and sets",
+			traversal: g.MergeV(map[interface{}]interface{}{T.Label: "person", "name": "marko"}).
+				Option(Merge.OnCreate, map[interface{}]interface{}{}).Inject([]interface{}{int32(1), "a"}),
+			groovy:      "g.mergeV(['name':'marko',(T.label):'person']).option(Merge.onCreate,[:]).inject([1,'a'])",
+			gremlinLang: "g.mergeV(['name':'marko',(T.label):'person']).option(Merge.onCreate,[:]).inject([1,'a'])",
+		},
+		{
+			name:        "bindings",
+			traversal:   g.V((&Bindings{}).Of("x", int32(1))).Out((&Bindings{}).Of("label", "knows")),
+			groovy:      "g.V(x).out(label)",
+			gremlinLang: "g.V(x).out(label)",
+		},
+		{
+			name: "strategies",
+			traversal: g.WithStrategies(ReadOnlyStrategy(), SubgraphStrategy(SubgraphStrategyConfig{
+				Vertices: T__.HasLabel("person")}), PartitionStrategy(PartitionStrategyConfig{
+				PartitionKey: "p", ReadPartitions: []string{"a", "b"}})).V(),
+			groovy: "g.withStrategies(new ReadOnlyStrategy(),new SubgraphStrategy(vertices:__.hasLabel('person'))," +
+				"new PartitionStrategy(includeMetaProperties:false,partitionKey:'p',readPartitions:['a','b'])).V()",
+			gremlinLang: "g.withStrategies(ReadOnlyStrategy,new SubgraphStrategy(vertices:__.hasLabel('person'))," +
+				"new PartitionStrategy(includeMetaProperties:false,partitionKey:'p',readPartitions:['a','b'])).V()",
+		},
+		{
+			name:      "with",
+			traversal: g.With("evaluationTimeout", int32(500)).With("userAgent", "x").WithStrategies(ReadOnlyStrategy()).V(),
+			groovy: "g.withStrategies(new OptionsStrategy(evaluationTimeout:500,userAgent:'x'))." +
+				"withStrategies(new ReadOnlyStrategy()).V()",
+			gremlinLang: "g.with('evaluationTimeout',500).with('userAgent','x').withStrategies(ReadOnlyStrategy).V()",
+		},
+		{
+			name: "dates and vertices",
+			traversal: g.Inject(time.Date(2018, 3, 21, 8, 35, 44, 741000000, time.UTC),
+				&Vertex{Element{Id: int32(1), Label: "person"}}),
+			groovy:      "g.inject(new Date(1521621344741L),new ReferenceVertex(1,'person'))",
+			gremlinLang: "g.inject(datetime('2018-03-21T08:35:44.741Z'),new ReferenceVertex(1,'person'))",
+		},
+	}
+	for _, test := range tests {
+		t.Run("Test translate "+test.name, func(t *testing.T) {
+			script, err := groovy.Translate(test.traversal.Bytecode)
+			assert.Nil(t, err)
+			assert.Equal(t, test.groovy, script)
+			script, err = gremlinLang.Translate(test.traversal.Bytecode)
+			assert.Nil(t, err)
+			assert.Equal(t, test.gremlinLang, script)
+		})
+	}
+
+	t.Run("Test translate gremlin-groovy only values", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			traversal *GraphTraversal
+			groovy    string
+			code      ErrorCode
+		}{
+			{
+				name:      "set",
+				traversal: g.Inject(NewSimpleSet("x")),
+				groovy:    "g.inject(['x'] as Set)",
+				code:      E1301TranslatorUnsupportedValueError,
+			},
+			{
+				name:      "uuid",
+				traversal: g.Inject(uuid.MustParse("41d2e28a-20a4-4ab0-b379-d810dede3786")),
+				groovy:    "g.inject(UUID.fromString('41d2e28a-20a4-4ab0-b379-d810dede3786'))",
+				code:      E1301TranslatorUnsupportedValueError,
+			},
+			{
+				name: "lambdas",
+				traversal: g.V().Map(&Lambda{Script: "it.get().value('name').length()"}).
+					Filter(&Lambda{Script: " {it.get()} "}),
+				groovy: "g.V().map({it.get().value('name').length()}).filter({it.get()})",
+				code:   E1302TranslatorUnsupportedLambdaError,
+			},
+			{
+				name:      "withoutStrategies",
+				traversal: g.WithoutStrategies(CountStrategy()).V(),
+				groovy:    "g.withoutStrategies(CountStrategy).V()",
+				code:      E1301TranslatorUnsupportedValueError,
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				script, err := groovy.Translate(test.traversal.Bytecode)
+				assert.Nil(t, err)
+				assert.Equal(t, test.groovy, script)
+				_, err = gremlinLang.Translate(test.traversal.Bytecode)
+				assert.True(t, isSameErrorCode(newError(test.code), err))
+			})
+		}
+	})
+
+	t.Run("Test translate traversal source name", func(t *testing.T) {
+		script, err := NewTranslator("social", GremlinGroovy).Translate(g.V().Count().Bytecode)
+		assert.Nil(t, err)
+		assert.Equal(t, "social.V().count()", script)
+	})
+
+	t.Run("Test translate transaction", func(t *testing.T) {
+		bytecode := NewBytecode(nil)
+		bytecode.AddSource("tx", "commit")
+		script, err := groovy.Translate(bytecode)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.tx().commit()", script)
+	})
+
+	t.Run("Test translate parameterized", func(t *testing.T) {
+		traversal := g.V(int64(1)).Has("person", "name", P.Within("marko", "josh")).Order().By("age", Order.Desc).
+			Where(T__.Out("knows")).Limit(int64(2))
+		script, bindings, err := groovy.translateParameterized(traversal.Bytecode, defaultScriptOnlyMaxParameters)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.V(_args_0).has(_args_1,_args_2,P.within(_args_3,_args_4)).order().by(_args_5,Order.desc)."+
+			"where(__.out(_args_6)).limit(_args_7)", script)
+		assert.Equal(t, map[string]interface{}{
+			"_args_0": int64(1),
+			"_args_1": "person",
+			"_args_2": "name",
+			"_args_3": "marko",
+			"_args_4": "josh",
+			"_args_5": "age",
+			"_args_6": "knows",
+			"_args_7": int64(2),
+		}, bindings)
+
+		other, _, err := groovy.translateParameterized(g.V(int64(2)).Has("software", "name", P.Within("lop", "ripple")).
+			Order().By("name", Order.Desc).Where(T__.Out("created")).Limit(int64(5)).Bytecode, defaultScriptOnlyMaxParameters)
+		assert.Nil(t, err)
+		assert.Equal(t, script, other)
+	})
+
+	t.Run("Test translate parameterized bindings and map keys", func(t *testing.T) {
+		traversal := g.V().Out((&Bindings{}).Of("label", "knows")).
+			Property(Cardinality.Single, "since", int32(2010)).
+			Fold().Inject(map[interface{}]interface{}{"name": "marko"})
+		script, bindings, err := groovy.translateParameterized(traversal.Bytecode, defaultScriptOnlyMaxParameters)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.V().out(label).property(VertexProperty.Cardinality.single,_args_0,_args_1).fold()."+
+			"inject(['name':_args_2])", script)
+		assert.Equal(t, map[string]interface{}{
+			"label":   "knows",
+			"_args_0": "since",
+			"_args_1": int32(2010),
+			"_args_2": "marko",
+		}, bindings)
+	})
+
+	t.Run("Test translate parameterized up to max parameters", func(t *testing.T) {
+		values := func(count int) []interface{} {
+			var values []interface{}
+			for i := 0; i < count; i++ {
+				values = append(values, int32(i))
+			}
+			return values
+		}
+		script, bindings, err := groovy.translateParameterized(g.Inject(values(16)...).Bytecode,
+			defaultScriptOnlyMaxParameters)
+		assert.Nil(t, err)
+		assert.Len(t, bindings, 16)
+		assert.True(t, strings.HasSuffix(script, ",_args_14,_args_15)"))
+
+		script, bindings, err = groovy.translateParameterized(g.Inject(values(17)...).Bytecode,
+			defaultScriptOnlyMaxParameters)
+		assert.Nil(t, err)
+		assert.Len(t, bindings, 16)
+		assert.Equal(t, int32(15), bindings["_args_15"])
+		assert.True(t, strings.HasSuffix(script, ",_args_14,_args_15,16)"))
+
+		// Bindings given with the traversal count towards the maximum.
+		script, bindings, err = groovy.translateParameterized(g.V((&Bindings{}).Of("id", int32(1))).
+			Has("name", "marko").Bytecode, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.V(id).has(_args_0,'marko')", script)
+		assert.Equal(t, map[string]interface{}{"id": int32(1), "_args_0": "name"}, bindings)
+
+		script, bindings, err = groovy.translateParameterized(g.V().Has("name", "marko").Bytecode, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, "g.V().has('name','marko')", script)
+		assert.Empty(t, bindings)
+	})
+
+	t.Run("Test translate unsupported value failure", func(t *testing.T) {
+		_, err := groovy.Translate(g.Inject(struct{}{}).Bytecode)
+		assert.True(t, isSameErrorCode(newError(E1301TranslatorUnsupportedValueError), err))
+		_, err = groovy.Translate(g.V().Map(&Lambda{Script: "lambda x: x", Language: "gremlin-python"}).Bytecode)
+		assert.True(t, isSameErrorCode(newError(E1302TranslatorUnsupportedLambdaError), err))
+	})
+}
@@ -19,20 +19,56 @@ under the License.
 
 package gremlingo
 
-import "time"
+import (
+	"crypto/tls"
+	"io"
+	"time"
+)
 
-type transporter interface {
+// Transporter is the transport layer through which a connection exchanges serialized messages with the server.
+// Custom implementations can be used by registering a TransporterFactory with RegisterTransporter.
+type Transporter interface {
+	// Connect establishes the transport. It may be called more than once and must be a no-op once connected.
 	Connect() error
+	// Write sends a serialized request message.
 	Write(data []byte) error
+	// Read blocks until a serialized response message is received. It must return an error once the Transporter is
+	// closed, which stops the connection from reading.
 	Read() ([]byte, error)
 	Close() error
 	IsClosed() bool
-	getAuthInfo() AuthInfoProvider
 }
 
+// StreamingTransporter is a Transporter which can return received messages as streams. Connections read messages with
+// NextReader instead of Read when their Transporter implements it, so that responses are deserialized while they are
+// received, without being buffered first.
+type StreamingTransporter interface {
+	Transporter
+	// NextReader blocks until a response message is received and returns a reader of its content. The reader is only
+	// valid until NextReader is called again. Like Read, it must return an error once the Transporter is closed.
+	NextReader() (io.Reader, error)
+}
+
+// TransporterSettings holds the settings of the Client or DriverRemoteConnection which concern the transport layer.
+type TransporterSettings struct {
+	AuthInfo                 AuthInfoProvider
+	TlsConfig                *tls.Config
+	KeepAliveInterval        time.Duration
+	WriteDeadline            time.Duration
+	ConnectionTimeout        time.Duration
+	EnableCompression        bool
+	ReadBufferSize           int
+	WriteBufferSize          int
+	EnableUserAgentOnConnect bool
+}
+
+// TransporterFactory creates a Transporter for a connection to the given url.
+type TransporterFactory func(url string, settings *TransporterSettings) (Transporter, error)
+
 type websocketConn interface {
 	WriteMessage(int, []byte) error
 	ReadMessage() (int, []byte, error)
+	NextReader() (int, io.Reader, error)
 	SetPongHandler(h func(appData string) error)
 	Close() error
 	SetReadDeadline(t time.Time) error
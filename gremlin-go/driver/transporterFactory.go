@@ -23,16 +23,37 @@ import (
 	"sync"
 )
 
-// TransporterType is an alias for valid transport protocols.
+// TransporterType selects the transport layer of a connection. Besides the built-in types, a TransporterType is
+// returned by RegisterTransporter for each custom transport.
 type TransporterType int
 
 const (
 	// Gorilla transport layer: github.com/gorilla/websocket
 	Gorilla TransporterType = iota + 1
+	// HTTP transport layer: requests are POSTed to the HTTP endpoint of Gremlin Server using net/http
+	HTTP
 )
 
-func getTransportLayer(transporterType TransporterType, url string, connSettings *connectionSettings, logHandler *logHandler) (transporter, error) {
-	var transporter transporter
+var customTransporters = struct {
+	sync.RWMutex
+	factories map[TransporterType]TransporterFactory
+	next      TransporterType
+}{factories: map[TransporterType]TransporterFactory{}, next: HTTP + 1}
+
+// RegisterTransporter registers a factory for a custom transport layer and returns the TransporterType which selects
+// it in ClientSettings or DriverRemoteConnectionSettings.
+func RegisterTransporter(factory TransporterFactory) TransporterType {
+	customTransporters.Lock()
+	defer customTransporters.Unlock()
+
+	transporterType := customTransporters.next
+	customTransporters.factories[transporterType] = factory
+	customTransporters.next++
+	return transporterType
+}
+
+func getTransportLayer(transporterType TransporterType, url string, connSettings *connectionSettings, logHandler *logHandler) (Transporter, error) {
+	var transporter Transporter
 	switch transporterType {
 	case Gorilla:
 		transporter = &gorillaTransporter{
@@ -42,8 +63,26 @@ func getTransportLayer(transporterType TransporterType, url string, connSettings
 			writeChannel: make(chan []byte, writeChannelSizeDefault),
 			wg:           &sync.WaitGroup{},
 		}
+	case HTTP:
+		transporter = &httpTransporter{
+			url:          url,
+			logHandler:   logHandler,
+			connSettings: connSettings,
+			responses:    make(chan []byte, writeChannelSizeDefault),
+			wg:           &sync.WaitGroup{},
+		}
 	default:
-		return nil, newError(err0801GetTransportLayerNoTypeError)
+		customTransporters.RLock()
+		factory, ok := customTransporters.factories[transporterType]
+		customTransporters.RUnlock()
+		if !ok {
+			return nil, newError(E0801GetTransportLayerNoTypeError)
+		}
+		var err error
+		transporter, err = factory(url, connSettings.transporterSettings())
+		if err != nil {
+			return nil, err
+		}
 	}
 	err := transporter.Connect()
 	if err != nil {
@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// pipeTransporter is an in-memory Transporter which answers every request with the given result.
+type pipeTransporter struct {
+	t         *testing.T
+	result    interface{}
+	responses chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (transporter *pipeTransporter) Connect() error {
+	return nil
+}
+
+func (transporter *pipeTransporter) Write(data []byte) error {
+	// The request id follows the mime type header and the version byte.
+	idOffset := int(data[0]) + 2
+	requestID, err := uuid.FromBytes(data[idOffset : idOffset+16])
+	assert.Nil(transporter.t, err)
+	select {
+	case transporter.responses <- graphBinaryTestResponse(transporter.t, requestID, []interface{}{transporter.result}):
+		return nil
+	case <-transporter.done:
+		return errors.New("pipe closed")
+	}
+}
+
+func (transporter *pipeTransporter) Read() ([]byte, error) {
+	select {
+	case response := <-transporter.responses:
+		return response, nil
+	case <-transporter.done:
+		return nil, errors.New("pipe closed")
+	}
+}
+
+func (transporter *pipeTransporter) Close() error {
+	transporter.closeOnce.Do(func() {
+		close(transporter.done)
+	})
+	return nil
+}
+
+func (transporter *pipeTransporter) IsClosed() bool {
+	select {
+	case <-transporter.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestTransporterFactory(t *testing.T) {
+	t.Run("Test custom transporter", func(t *testing.T) {
+		var lock sync.Mutex
+		var receivedURL string
+		var receivedSettings *TransporterSettings
+		transporterType := RegisterTransporter(func(url string, settings *TransporterSettings) (Transporter, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			receivedURL = url
+			receivedSettings = settings
+			return &pipeTransporter{t: t, result: "piped", responses: make(chan []byte, 1),
+				done: make(chan struct{})}, nil
+		})
+		assert.NotEqual(t, Gorilla, transporterType)
+		assert.NotEqual(t, HTTP, transporterType)
+
+		authInfo := BasicAuthInfo("user", "pass")
+		client, err := NewClient("pipe://graph", func(settings *ClientSettings) {
+			settings.TransporterType = transporterType
+			settings.AuthInfo = authInfo
+			settings.ConnectionTimeout = 3 * time.Second
+		})
+		assert.Nil(t, err)
+		defer client.Close()
+
+		resultSet, err := client.Submit("g.V()")
+		assert.Nil(t, err)
+		result, ok, err := resultSet.One()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "piped", result.GetString())
+
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, "pipe://graph", receivedURL)
+		assert.Equal(t, authInfo, receivedSettings.AuthInfo)
+		assert.Equal(t, 3*time.Second, receivedSettings.ConnectionTimeout)
+		assert.True(t, receivedSettings.EnableUserAgentOnConnect)
+	})
+
+	t.Run("Test custom transporter factory error", func(t *testing.T) {
+		transporterType := RegisterTransporter(func(url string, settings *TransporterSettings) (Transporter, error) {
+			return nil, errors.New("bastion unreachable")
+		})
+		client, err := NewClient("pipe://graph", func(settings *ClientSettings) {
+			settings.TransporterType = transporterType
+			settings.LogVerbosity = Error
+		})
+		assert.Nil(t, client)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "bastion unreachable")
+	})
+
+	t.Run("Test unknown transporter type", func(t *testing.T) {
+		transporter, err := getTransportLayer(TransporterType(-1), "pipe://graph", newDefaultConnectionSettings(),
+			newLogHandler(&defaultLogger{}, Error, language.English))
+		assert.Nil(t, transporter)
+		assert.True(t, isSameErrorCode(newError(E0801GetTransportLayerNoTypeError), err))
+	})
+}
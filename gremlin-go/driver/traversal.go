@@ -19,7 +19,10 @@ under the License.
 
 package gremlingo
 
-import "math/big"
+import (
+	"context"
+	"math/big"
+)
 
 // Traverser is the objects propagating through the traversal.
 type Traverser struct {
@@ -27,6 +30,16 @@ type Traverser struct {
 	value interface{}
 }
 
+// Bulk returns how many traversers the Traverser represents.
+func (t *Traverser) Bulk() int64 {
+	return t.bulk
+}
+
+// Value returns the object the Traverser is at.
+func (t *Traverser) Value() interface{} {
+	return t.value
+}
+
 // Traversal is the primary way in which graphs are processed.
 type Traversal struct {
 	graph    *Graph
@@ -37,20 +50,54 @@ type Traversal struct {
 
 // ToList returns the result in a list.
 func (t *Traversal) ToList() ([]*Result, error) {
+	return t.ToListContext(context.Background())
+}
+
+// ToListContext returns the result in a list. If ctx is done before all results are received, the traversal stops
+// waiting and returns ctx.Err().
+func (t *Traversal) ToListContext(ctx context.Context) ([]*Result, error) {
 	if t.remote == nil {
-		return nil, newError(err0901ToListAnonTraversalError)
+		return nil, newError(E0901ToListAnonTraversalError)
 	}
 
-	results, err := t.remote.submitBytecode(t.Bytecode)
+	results, err := t.remote.submitBytecodeContext(ctx, t.Bytecode)
 	if err != nil {
 		return nil, err
 	}
-	return results.All()
+	return results.AllContext(ctx)
+}
+
+// All returns an iterator over the results of the traversal, which yields each result as it is received. The
+// traversal is submitted each time the iteration starts, and a failure is yielded as the last error.
+func (t *Traversal) All() Seq[*Result] {
+	return t.AllContext(context.Background())
+}
+
+// AllContext is All, except that it stops waiting once ctx is done. In that case ctx.Err() is yielded.
+func (t *Traversal) AllContext(ctx context.Context) Seq[*Result] {
+	return func(yield func(*Result, error) bool) {
+		if t.remote == nil {
+			yield(nil, newError(E0901ToListAnonTraversalError))
+			return
+		}
+		results, err := t.remote.submitBytecodeContext(ctx, t.Bytecode)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		results.IterContext(ctx)(yield)
+	}
 }
 
 // ToSet returns the results in a set.
 func (t *Traversal) ToSet() (map[*Result]bool, error) {
-	list, err := t.ToList()
+	return t.ToSetContext(context.Background())
+}
+
+// ToSetContext returns the results in a set. If ctx is done before all results are received, the traversal stops
+// waiting and returns ctx.Err().
+func (t *Traversal) ToSetContext(ctx context.Context) (map[*Result]bool, error) {
+	list, err := t.ToListContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -64,13 +111,19 @@ func (t *Traversal) ToSet() (map[*Result]bool, error) {
 
 // Iterate all the Traverser instances in the traversal.
 func (t *Traversal) Iterate() <-chan error {
+	return t.IterateContext(context.Background())
+}
+
+// IterateContext iterates all the Traverser instances in the traversal. If ctx is done before the traversal completes,
+// ctx.Err() is sent on the returned channel.
+func (t *Traversal) IterateContext(ctx context.Context) <-chan error {
 	r := make(chan error)
 
 	go func() {
 		defer close(r)
 
 		if t.remote == nil {
-			r <- newError(err0902IterateAnonTraversalError)
+			r <- newError(E0902IterateAnonTraversalError)
 			return
 		}
 
@@ -79,14 +132,14 @@ func (t *Traversal) Iterate() <-chan error {
 			return
 		}
 
-		res, err := t.remote.submitBytecode(t.Bytecode)
+		res, err := t.remote.submitBytecodeContext(ctx, t.Bytecode)
 		if err != nil {
 			r <- err
 			return
 		}
 
 		// Force waiting until complete.
-		_, err = res.All()
+		_, err = res.AllContext(ctx)
 		r <- err
 	}()
 
@@ -95,30 +148,55 @@ func (t *Traversal) Iterate() <-chan error {
 
 // HasNext returns true if the result is not empty.
 func (t *Traversal) HasNext() (bool, error) {
-	results, err := t.GetResultSet()
+	return t.HasNextContext(context.Background())
+}
+
+// HasNextContext returns true if the result is not empty. If ctx is done while waiting for the server, ctx.Err() is
+// returned.
+func (t *Traversal) HasNextContext(ctx context.Context) (bool, error) {
+	results, err := t.GetResultSetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	empty, err := results.isEmptyContext(ctx)
 	if err != nil {
 		return false, err
 	}
-	return !results.IsEmpty(), nil
+	return !empty, nil
 }
 
 // Next returns next result.
 func (t *Traversal) Next() (*Result, error) {
-	results, err := t.GetResultSet()
+	return t.NextContext(context.Background())
+}
+
+// NextContext returns next result. If ctx is done while waiting for the server, ctx.Err() is returned.
+func (t *Traversal) NextContext(ctx context.Context) (*Result, error) {
+	results, err := t.GetResultSetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	empty, err := results.isEmptyContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if results.IsEmpty() {
-		return nil, newError(err0903NextNoResultsLeftError)
+	if empty {
+		return nil, newError(E0903NextNoResultsLeftError)
 	}
-	result, _, err := results.One()
+	result, _, err := results.OneContext(ctx)
 	return result, err
 }
 
 // GetResultSet submits the traversal and returns the ResultSet.
 func (t *Traversal) GetResultSet() (ResultSet, error) {
+	return t.GetResultSetContext(context.Background())
+}
+
+// GetResultSetContext submits the traversal and returns the ResultSet. The ResultSet is cancelled once ctx is done,
+// unless the traversal was already submitted before.
+func (t *Traversal) GetResultSetContext(ctx context.Context) (ResultSet, error) {
 	if t.results == nil {
-		results, err := t.remote.submitBytecode(t.Bytecode)
+		results, err := t.remote.submitBytecodeContext(ctx, t.Bytecode)
 		if err != nil {
 			return nil, err
 		}
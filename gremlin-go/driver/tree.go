@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"reflect"
+)
+
+// Tree is the result of the tree step. Each node of a Tree holds an object of the traversed paths and the subtree of
+// the objects which followed it. The children of a Tree are kept in the order they were received in.
+type Tree struct {
+	Children []*TreeNode
+}
+
+// TreeNode is a node of a Tree which is keyed by an object, such as a Vertex, and holds the subtree below it.
+type TreeNode struct {
+	Key interface{}
+	Tree
+}
+
+// Get returns the child keyed by the given object, or nil if there is no such child. Elements are matched by their
+// type and Id and other keys by their value.
+func (t *Tree) Get(key interface{}) *TreeNode {
+	for _, child := range t.Children {
+		if sameTreeKey(child.Key, key) {
+			return child
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys of the children of the Tree.
+func (t *Tree) Keys() []interface{} {
+	keys := make([]interface{}, 0, len(t.Children))
+	for _, child := range t.Children {
+		keys = append(keys, child.Key)
+	}
+	return keys
+}
+
+// Walk visits the nodes of the Tree depth first, calling fn with each node and its depth, where the children of the
+// Tree have depth 1. The subtree below a node is skipped if fn returns false.
+func (t *Tree) Walk(fn func(node *TreeNode, depth int) bool) {
+	t.walk(fn, 1)
+}
+
+func (t *Tree) walk(fn func(node *TreeNode, depth int) bool, depth int) {
+	for _, child := range t.Children {
+		if fn(child, depth) {
+			child.walk(fn, depth+1)
+		}
+	}
+}
+
+// NodesAtDepth returns the nodes at the given depth of the Tree, where the children of the Tree have depth 1.
+func (t *Tree) NodesAtDepth(depth int) []*TreeNode {
+	var nodes []*TreeNode
+	t.Walk(func(node *TreeNode, nodeDepth int) bool {
+		if nodeDepth == depth {
+			nodes = append(nodes, node)
+		}
+		return nodeDepth < depth
+	})
+	return nodes
+}
+
+// ObjectsAtDepth returns the keys of the nodes at the given depth of the Tree, where the children of the Tree have
+// depth 1.
+func (t *Tree) ObjectsAtDepth(depth int) []interface{} {
+	var objects []interface{}
+	for _, node := range t.NodesAtDepth(depth) {
+		objects = append(objects, node.Key)
+	}
+	return objects
+}
+
+// Leaves returns the nodes of the Tree which have no children.
+func (t *Tree) Leaves() []*TreeNode {
+	var leaves []*TreeNode
+	t.Walk(func(node *TreeNode, depth int) bool {
+		if len(node.Children) == 0 {
+			leaves = append(leaves, node)
+		}
+		return true
+	})
+	return leaves
+}
+
+// LeafObjects returns the keys of the nodes of the Tree which have no children.
+func (t *Tree) LeafObjects() []interface{} {
+	var objects []interface{}
+	for _, leaf := range t.Leaves() {
+		objects = append(objects, leaf.Key)
+	}
+	return objects
+}
+
+func sameTreeKey(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *Vertex:
+		y, ok := b.(*Vertex)
+		return ok && reflect.DeepEqual(x.Id, y.Id)
+	case *Edge:
+		y, ok := b.(*Edge)
+		return ok && reflect.DeepEqual(x.Id, y.Id)
+	case *VertexProperty:
+		y, ok := b.(*VertexProperty)
+		return ok && reflect.DeepEqual(x.Id, y.Id)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTree returns the tree of g.V(1).out().out() over the modern graph:
+// v[1] -> v[4] -> (v[3], v[5])
+func newTestTree() *Tree {
+	vertex := func(id int32) *Vertex {
+		return &Vertex{Element{Id: id, Label: "person"}}
+	}
+	leaf := func(id int32) *TreeNode {
+		return &TreeNode{Key: vertex(id)}
+	}
+	return &Tree{Children: []*TreeNode{
+		{Key: vertex(1), Tree: Tree{Children: []*TreeNode{
+			{Key: vertex(4), Tree: Tree{Children: []*TreeNode{leaf(3), leaf(5)}}},
+		}}},
+	}}
+}
+
+func TestTree(t *testing.T) {
+	t.Run("Test Get", func(t *testing.T) {
+		tree := newTestTree()
+		node := tree.Get(&Vertex{Element{Id: int32(1)}})
+		assert.NotNil(t, node)
+		assert.Equal(t, int32(4), node.Get(&Vertex{Element{Id: int32(4)}}).Key.(*Vertex).Id)
+		assert.Nil(t, tree.Get(&Vertex{Element{Id: int32(4)}}))
+		assert.Nil(t, tree.Get(&Edge{Element: Element{Id: int32(1)}}))
+
+		valueTree := &Tree{Children: []*TreeNode{{Key: "marko"}, {Key: int64(29)}}}
+		assert.Equal(t, int64(29), valueTree.Get(int64(29)).Key)
+		assert.Nil(t, valueTree.Get(int32(29)))
+		assert.Equal(t, []interface{}{"marko", int64(29)}, valueTree.Keys())
+	})
+
+	t.Run("Test Walk", func(t *testing.T) {
+		var visited []interface{}
+		var depths []int
+		newTestTree().Walk(func(node *TreeNode, depth int) bool {
+			visited = append(visited, node.Key.(*Vertex).Id)
+			depths = append(depths, depth)
+			return true
+		})
+		assert.Equal(t, []interface{}{int32(1), int32(4), int32(3), int32(5)}, visited)
+		assert.Equal(t, []int{1, 2, 3, 3}, depths)
+
+		visited = nil
+		newTestTree().Walk(func(node *TreeNode, depth int) bool {
+			visited = append(visited, node.Key.(*Vertex).Id)
+			return depth < 2
+		})
+		assert.Equal(t, []interface{}{int32(1), int32(4)}, visited)
+	})
+
+	t.Run("Test ObjectsAtDepth", func(t *testing.T) {
+		tree := newTestTree()
+		assert.Equal(t, []interface{}{tree.Children[0].Key}, tree.ObjectsAtDepth(1))
+		assert.Len(t, tree.NodesAtDepth(3), 2)
+		assert.Equal(t, int32(5), tree.ObjectsAtDepth(3)[1].(*Vertex).Id)
+		assert.Nil(t, tree.ObjectsAtDepth(4))
+		assert.Nil(t, tree.ObjectsAtDepth(0))
+	})
+
+	t.Run("Test Leaves", func(t *testing.T) {
+		tree := newTestTree()
+		leaves := tree.LeafObjects()
+		assert.Len(t, leaves, 2)
+		assert.Equal(t, int32(3), leaves[0].(*Vertex).Id)
+		assert.Equal(t, int32(5), leaves[1].(*Vertex).Id)
+		assert.Len(t, tree.Leaves(), 2)
+		assert.Nil(t, (&Tree{}).Leaves())
+	})
+}
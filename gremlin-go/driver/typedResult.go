@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"reflect"
+)
+
+// As returns the data of the Result as a T. Numbers are converted to any numeric type which holds their value,
+// including *big.Int and BigDecimal, and other values must be of type T. Nil data is returned as the zero value of
+// types which can be nil, and so is a nil Result, as returned by ResultSet.One once the results are exhausted.
+//
+//	count, err := gremlingo.As[int64](result)
+func As[T any](r *Result) (T, error) {
+	data := resultData(r)
+	if value, ok := data.(T); ok {
+		return value, nil
+	}
+	var value T
+	err := assertResult(data, reflect.ValueOf(&value).Elem())
+	return value, err
+}
+
+// ResultsAs returns all remaining results of the ResultSet as T, as converted by As.
+func ResultsAs[T any](resultSet ResultSet) ([]T, error) {
+	results, err := resultSet.All()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]T, len(results))
+	for i, result := range results {
+		if values[i], err = As[T](result); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// MapAs returns the data of the Result, which must be a map, as a map[K]V, where the keys and the values are converted
+// as by As. A nil Result is returned as a nil map.
+func MapAs[K comparable, V any](r *Result) (map[K]V, error) {
+	return As[map[K]V](r)
+}
+
+// resultData returns the data of the Result, which is nil for a nil Result.
+func resultData(r *Result) interface{} {
+	if r == nil {
+		return nil
+	}
+	return r.Data
+}
+
+// assertResult stores src in dst if it is of the type of dst. Numbers are also stored if dst is of a numeric type which
+// holds their value, and strings of other string types, such as the enum T, are stored if dst is a string. Lists, sets
+// and maps are stored in slices and maps of other types, converting each of their items, keys and values.
+func assertResult(src interface{}, dst reflect.Value) error {
+	typ := dst.Type()
+	if src == nil {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return nil
+		}
+		return newError(E0614ResultAsTypeError, src, typ)
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(typ) {
+		dst.Set(srcValue)
+		return nil
+	}
+	if _, ok := toBigRat(src); ok {
+		if typ.Kind() == reflect.Ptr && (typ.Elem() == bigIntReflectType || typ.Elem() == bigDecimalReflectType) {
+			dst.Set(reflect.New(typ.Elem()))
+			return decodeNumber(src, dst.Elem(), "result")
+		}
+		if isNumericType(typ) {
+			return decodeNumber(src, dst, "result")
+		}
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		if srcValue.Kind() == reflect.String {
+			dst.SetString(srcValue.String())
+			return nil
+		}
+	case reflect.Slice:
+		if items, ok := resultItems(src); ok {
+			return assertItems(items, dst)
+		}
+	case reflect.Map:
+		if srcValue.Kind() == reflect.Map {
+			return assertEntries(srcValue, dst)
+		}
+	}
+	return newError(E0614ResultAsTypeError, src, typ)
+}
+
+func assertItems(items []interface{}, dst reflect.Value) error {
+	values := reflect.MakeSlice(dst.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := assertResult(item, values.Index(i)); err != nil {
+			return err
+		}
+	}
+	dst.Set(values)
+	return nil
+}
+
+func assertEntries(entries reflect.Value, dst reflect.Value) error {
+	typ := dst.Type()
+	values := reflect.MakeMapWithSize(typ, entries.Len())
+	iter := entries.MapRange()
+	for iter.Next() {
+		key := reflect.New(typ.Key()).Elem()
+		if err := assertResult(iter.Key().Interface(), key); err != nil {
+			return err
+		}
+		value := reflect.New(typ.Elem()).Elem()
+		if err := assertResult(iter.Value().Interface(), value); err != nil {
+			return err
+		}
+		values.SetMapIndex(key, value)
+	}
+	dst.Set(values)
+	return nil
+}
+
+func isNumericType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return typ == bigIntReflectType || typ == bigDecimalReflectType
+}
@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package gremlingo
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedResult(t *testing.T) {
+	t.Run("Test As type assertion", func(t *testing.T) {
+		vertex := &Vertex{Element{Id: int64(1), Label: "person"}}
+		v, err := As[*Vertex](&Result{vertex})
+		assert.Nil(t, err)
+		assert.Same(t, vertex, v)
+
+		s, err := As[string](&Result{"marko"})
+		assert.Nil(t, err)
+		assert.Equal(t, "marko", s)
+
+		label, err := As[string](&Result{T.Label})
+		assert.Nil(t, err)
+		assert.Equal(t, "label", label)
+
+		list, err := As[[]interface{}](&Result{[]interface{}{int32(1)}})
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{int32(1)}, list)
+	})
+
+	t.Run("Test As numeric widening", func(t *testing.T) {
+		i64, err := As[int64](&Result{int32(29)})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(29), i64)
+
+		i32, err := As[int32](&Result{int64(29)})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(29), i32)
+
+		f, err := As[float64](&Result{int32(29)})
+		assert.Nil(t, err)
+		assert.Equal(t, 29.0, f)
+
+		bigInt, err := As[*big.Int](&Result{int64(math.MaxInt64)})
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(math.MaxInt64), bigInt)
+
+		huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		decimal, err := As[BigDecimal](&Result{huge})
+		assert.Nil(t, err)
+		assert.Equal(t, BigDecimal{Scale: 0, UnscaledValue: *huge}, decimal)
+
+		fromDecimal, err := As[int64](&Result{&BigDecimal{Scale: -2, UnscaledValue: *big.NewInt(3)}})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(300), fromDecimal)
+
+		decimalPointer, err := As[*BigDecimal](&Result{int32(7)})
+		assert.Nil(t, err)
+		assert.Equal(t, &BigDecimal{Scale: 0, UnscaledValue: *big.NewInt(7)}, decimalPointer)
+	})
+
+	t.Run("Test As nil", func(t *testing.T) {
+		v, err := As[*Vertex](&Result{nil})
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+		_, err = As[int64](&Result{nil})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+
+		// ResultSet.One returns a nil Result once the results are exhausted.
+		resultSet := newChannelResultSet("mockID", getSyncMap())
+		resultSet.Close()
+		result, ok, err := resultSet.One()
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		v, err = As[*Vertex](result)
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+		_, err = As[int64](result)
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+		counts, err := MapAs[string, int64](result)
+		assert.Nil(t, err)
+		assert.Nil(t, counts)
+	})
+
+	t.Run("Test As failure", func(t *testing.T) {
+		_, err := As[int64](&Result{"29"})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+		_, err = As[*Edge](&Result{&Vertex{}})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+		huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+		_, err = As[int64](&Result{huge})
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), err))
+		_, err = As[int32](&Result{2.5})
+		assert.True(t, isSameErrorCode(newError(E0613ResultDecodeOverflowError), err))
+	})
+
+	t.Run("Test MapAs", func(t *testing.T) {
+		counts, err := MapAs[string, int64](&Result{map[interface{}]interface{}{"person": int32(4), T.Label: int64(2)}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int64{"person": 4, "label": 2}, counts)
+
+		_, err = MapAs[string, int64](&Result{[]interface{}{}})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+		_, err = MapAs[string, int64](&Result{map[interface{}]interface{}{"person": "four"}})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+	})
+
+	t.Run("Test As containers", func(t *testing.T) {
+		names, err := As[[]string](&Result{[]interface{}{"a", "b"}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, names)
+		ages, err := As[[]int64](&Result{NewSimpleSet(int32(29))})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{29}, ages)
+		nested, err := As[[][]int32](&Result{[]interface{}{[]interface{}{int8(1)}, []interface{}{}}})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]int32{{1}, {}}, nested)
+
+		_, err = As[[]string](&Result{[]interface{}{"a", int32(1)}})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+		_, err = As[[]string](&Result{"a"})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+	})
+
+	t.Run("Test MapAs containers", func(t *testing.T) {
+		valueMap := map[interface{}]interface{}{"name": []interface{}{"marko"}, "location": []interface{}{"santa fe", "brussels"}}
+		locations, err := MapAs[string, []string](&Result{valueMap})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]string{"name": {"marko"}, "location": {"santa fe", "brussels"}}, locations)
+		valueMap["age"] = []interface{}{int32(29)}
+		_, err = MapAs[string, []string](&Result{valueMap})
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+
+		groups, err := MapAs[string, []int64](&Result{map[interface{}]interface{}{"person": []interface{}{int32(1), int64(2)}}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]int64{"person": {1, 2}}, groups)
+		nested, err := As[[]map[string]int64](&Result{[]interface{}{map[interface{}]interface{}{"a": int32(1)}}})
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]int64{{"a": 1}}, nested)
+	})
+
+	t.Run("Test ResultsAs", func(t *testing.T) {
+		resultSet := newChannelResultSet("mockID", getSyncMap())
+		resultSet.addResult(&Result{[]interface{}{int32(1), int64(2), big.NewInt(3)}})
+		resultSet.Close()
+		values, err := ResultsAs[int64](resultSet)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, values)
+
+		resultSet = newChannelResultSet("mockID", getSyncMap())
+		resultSet.addResult(&Result{"one"})
+		resultSet.Close()
+		_, err = ResultsAs[int64](resultSet)
+		assert.True(t, isSameErrorCode(newError(E0614ResultAsTypeError), err))
+	})
+}